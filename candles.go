@@ -0,0 +1,360 @@
+package poloniex
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	CANDLEBUFFER = 256    // Candle Update Channel Size
+	WEEKOFFSET   = 345600 // Seconds From The Epoch To Monday 00:00 UTC
+)
+
+// Candle of the aggregator.
+// Closed is set once the interval of the candle is over.
+type CandleUpdate struct {
+	Candle CandleStick `json:"candle"`
+	Closed bool        `json:"closed"`
+}
+
+// Candle aggregator.
+// It builds candles of any interval from trades, in-progress candles are
+// sent on every trade and closed candles when their interval is over.
+// Intervals are aligned as by candleStart, intervals without trades
+// produce no candle.
+type CandleAggregator struct {
+	C         <-chan CandleUpdate // updates, closed by Close
+	ch        chan CandleUpdate
+	done      chan struct{} // stops blocked sends, closed by Close
+	interval  int64         // seconds
+	current   *CandleStick
+	end       int64 // end of the current candle in seconds
+	closed    bool
+	sendMutex sync.Mutex // orders sends, held while sending
+	sync.Mutex
+}
+
+// Check candle interval, it must be a positive number of seconds.
+func candleInterval(interval time.Duration) (int64, error) {
+	if interval < time.Second || interval%time.Second != 0 {
+		return 0, Error(CandleIntervalError, interval.String())
+	}
+	return int64(interval / time.Second), nil
+}
+
+// Get start of the candle of interval containing sec.
+// Intervals of whole weeks start on Monday 00:00 UTC,
+// other intervals are aligned to the unix epoch.
+func candleStart(sec, interval int64) int64 {
+	var offset int64
+	if interval%(7*86400) == 0 {
+		offset = WEEKOFFSET
+	}
+
+	rem := (sec - offset) % interval
+	if rem < 0 {
+		rem += interval
+	}
+	return sec - rem
+}
+
+// Create candle aggregator of interval.
+func NewCandleAggregator(interval time.Duration) (*CandleAggregator, error) {
+	seconds, err := candleInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan CandleUpdate, CANDLEBUFFER)
+	return &CandleAggregator{
+		C:        ch,
+		ch:       ch,
+		done:     make(chan struct{}),
+		interval: seconds,
+	}, nil
+}
+
+// Add public trade.
+func (a *CandleAggregator) AddTrade(trade PublicTrade) {
+	rate, _ := trade.Rate.Float64()
+	amount, _ := trade.Amount.Float64()
+	total, _ := trade.Total.Float64()
+	a.add(trade.Date.Time, rate, amount, total)
+}
+
+// Add push trade received at the given time.
+// Push trades carry no date, so the receive time is used.
+func (a *CandleAggregator) AddNewTrade(trade NewTrade, at time.Time) {
+	a.add(at, trade.Rate, trade.Amount, trade.Total)
+}
+
+// Add the trades of push market updates received at the given time.
+func (a *CandleAggregator) AddMarketUpdates(updates []MarketUpdate, at time.Time) {
+	for _, v := range updates {
+		if v.TypeUpdate != NewTradeUpdate {
+			continue
+		}
+		if trade, ok := v.Data.(NewTrade); ok {
+			a.AddNewTrade(trade, at)
+		}
+	}
+}
+
+// sub-function for adding one trade.
+func (a *CandleAggregator) add(at time.Time, rate, amount, total float64) {
+	a.sendMutex.Lock()
+	defer a.sendMutex.Unlock()
+
+	a.Lock()
+	updates := a.addUpdates(at, rate, amount, total)
+	a.Unlock()
+
+	a.send(updates)
+}
+
+// Add one trade and get the updates to send.
+// It must be called with the lock held.
+func (a *CandleAggregator) addUpdates(at time.Time, rate, amount, total float64) (updates []CandleUpdate) {
+	if a.closed {
+		return
+	}
+
+	sec := at.Unix()
+	start := candleStart(sec, a.interval)
+
+	if a.current != nil && sec >= a.end {
+		updates = append(updates, CandleUpdate{Candle: *a.current, Closed: true})
+		a.current = nil
+	}
+
+	// late trades of earlier candles are dropped.
+	if a.current == nil && sec < a.end || a.current != nil && sec < a.end-a.interval {
+		return
+	}
+
+	if a.current == nil {
+		a.current = &CandleStick{
+			Date: PoloTime{time.Unix(start, 0).UTC()},
+			High: rate,
+			Low:  rate,
+			Open: rate,
+		}
+		a.end = start + a.interval
+	}
+
+	addTrade(a.current, rate, amount, total)
+	return append(updates, CandleUpdate{Candle: *a.current})
+}
+
+// Close the current candle if its interval is over at now.
+func (a *CandleAggregator) Tick(now time.Time) {
+	a.sendMutex.Lock()
+	defer a.sendMutex.Unlock()
+
+	var updates []CandleUpdate
+
+	a.Lock()
+	if !a.closed && a.current != nil && now.Unix() >= a.end {
+		updates = append(updates, CandleUpdate{Candle: *a.current, Closed: true})
+		a.current = nil
+	}
+	a.Unlock()
+
+	a.send(updates)
+}
+
+// Send updates without the lock held.
+// Closed candles are delivered unless the aggregator is closed meanwhile,
+// in-progress ones are dropped when the channel is full.
+// It must be called with sendMutex held.
+func (a *CandleAggregator) send(updates []CandleUpdate) {
+	for _, update := range updates {
+		if update.Closed {
+			select {
+			case a.ch <- update:
+			case <-a.done:
+				return
+			}
+			continue
+		}
+
+		select {
+		case a.ch <- update:
+		case <-a.done:
+			return
+		default:
+		}
+	}
+}
+
+// Aggregate the trades of push updates until the channel is closed,
+// c is the channel of Subscription or PoolSubscription.
+// The aggregator is closed with the channel.
+func (a *CandleAggregator) Follow(c <-chan interface{}) {
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		defer a.Close()
+
+		for {
+			select {
+			case update, ok := <-c:
+				if !ok {
+					return
+				}
+				if updates, ok := update.([]MarketUpdate); ok {
+					a.AddMarketUpdates(updates, time.Now())
+				}
+
+			case now := <-ticker.C:
+				a.Tick(now)
+			}
+		}
+	}()
+}
+
+// Close aggregator and its channel.
+// The in-progress candle is not sent, a blocked send is abandoned.
+func (a *CandleAggregator) Close() {
+	a.Lock()
+	if a.closed {
+		a.Unlock()
+		return
+	}
+	a.closed = true
+	close(a.done)
+	a.Unlock()
+
+	// wait for a send in progress before closing the channel.
+	a.sendMutex.Lock()
+	close(a.ch)
+	a.sendMutex.Unlock()
+}
+
+// Add trade to candle, volume is in base currency and quote volume
+// in quote currency.
+func addTrade(candle *CandleStick, rate, amount, total float64) {
+	if rate > candle.High {
+		candle.High = rate
+	}
+	if rate < candle.Low {
+		candle.Low = rate
+	}
+
+	candle.Close = rate
+	candle.Volume += total
+	candle.QuoteVolume += amount
+
+	if candle.QuoteVolume > 0 {
+		candle.WeightedAverage = candle.Volume / candle.QuoteVolume
+	} else {
+		candle.WeightedAverage = rate
+	}
+}
+
+// Build candles of interval from public trades.
+func CandlesFromTrades(trades []PublicTrade, interval time.Duration) (candles []CandleStick, err error) {
+	seconds, err := candleInterval(interval)
+	if err != nil {
+		return
+	}
+
+	sorted := make([]PublicTrade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Date.Equal(sorted[j].Date.Time) {
+			return sorted[i].TradeID < sorted[j].TradeID
+		}
+		return sorted[i].Date.Before(sorted[j].Date.Time)
+	})
+
+	for _, v := range sorted {
+		rate, _ := v.Rate.Float64()
+		amount, _ := v.Amount.Float64()
+		total, _ := v.Total.Float64()
+
+		sec := v.Date.Unix()
+		start := candleStart(sec, seconds)
+
+		n := len(candles)
+		if n == 0 || candles[n-1].Date.Unix() != start {
+			candles = append(candles, CandleStick{
+				Date: PoloTime{time.Unix(start, 0).UTC()},
+				High: rate,
+				Low:  rate,
+				Open: rate,
+			})
+			n++
+		}
+		addTrade(&candles[n-1], rate, amount, total)
+	}
+	return
+}
+
+// Resample candles to a coarser interval.
+// Candles are grouped by the interval containing their date, the interval
+// must be a multiple of the candle interval, the smallest distance between
+// candles. The zero-dated candle of empty chart data is skipped.
+func ResampleCandles(candles []CandleStick, interval time.Duration) (resampled []CandleStick, err error) {
+	seconds, err := candleInterval(interval)
+	if err != nil {
+		return
+	}
+
+	sorted := make([]CandleStick, 0, len(candles))
+	for _, v := range candles {
+		if v.Date.Unix() > 0 {
+			sorted = append(sorted, v)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date.Time)
+	})
+
+	if source := candlesInterval(sorted); source > 0 && seconds%source != 0 {
+		err = Error(ResampleIntervalError, interval.String()+" / "+(time.Duration(source)*time.Second).String())
+		return
+	}
+
+	for _, v := range sorted {
+		sec := v.Date.Unix()
+		start := candleStart(sec, seconds)
+
+		n := len(resampled)
+		if n == 0 || resampled[n-1].Date.Unix() != start {
+			v.Date = PoloTime{time.Unix(start, 0).UTC()}
+			resampled = append(resampled, v)
+			continue
+		}
+
+		candle := &resampled[n-1]
+		if v.High > candle.High {
+			candle.High = v.High
+		}
+		if v.Low < candle.Low {
+			candle.Low = v.Low
+		}
+
+		candle.Close = v.Close
+		candle.Volume += v.Volume
+		candle.QuoteVolume += v.QuoteVolume
+
+		if candle.QuoteVolume > 0 {
+			candle.WeightedAverage = candle.Volume / candle.QuoteVolume
+		}
+	}
+	return
+}
+
+// Get interval of sorted candles in seconds, the smallest distance
+// between their dates. It is zero for less than two candles.
+func candlesInterval(candles []CandleStick) (interval int64) {
+	for i := 1; i < len(candles); i++ {
+		d := candles[i].Date.Unix() - candles[i-1].Date.Unix()
+		if d > 0 && (interval == 0 || d < interval) {
+			interval = d
+		}
+	}
+	return
+}
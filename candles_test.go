@@ -0,0 +1,228 @@
+package poloniex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestCandleStart(t *testing.T) {
+	const week = 7 * 86400
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		interval int64
+		want     time.Time
+	}{
+		{"minute", time.Date(2026, 10, 14, 12, 34, 56, 0, time.UTC), 60, time.Date(2026, 10, 14, 12, 34, 0, 0, time.UTC)},
+		{"day", date(2026, 10, 14, 12), 86400, date(2026, 10, 14, 0)},
+		{"week from wednesday", date(2026, 10, 14, 12), week, date(2026, 10, 12, 0)},
+		{"week from monday", date(2026, 10, 12, 0), week, date(2026, 10, 12, 0)},
+		{"week from sunday", date(2026, 10, 18, 23), week, date(2026, 10, 12, 0)},
+		{"two weeks", date(2026, 10, 21, 12), 2 * week, date(2026, 10, 12, 0)},
+		{"week before epoch monday", date(1970, 1, 2, 0), week, date(1969, 12, 29, 0)},
+	}
+
+	for _, tt := range tests {
+		got := time.Unix(candleStart(tt.at.Unix(), tt.interval), 0).UTC()
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: candleStart(%v) = %v, want %v", tt.name, tt.at, got, tt.want)
+		}
+		if tt.interval%week == 0 && got.Weekday() != time.Monday {
+			t.Errorf("%s: weekly candle starts on %v", tt.name, got.Weekday())
+		}
+	}
+}
+
+func TestCandleInterval(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		want     int64
+		ok       bool
+	}{
+		{time.Minute, 60, true},
+		{time.Second, 1, true},
+		{0, 0, false},
+		{500 * time.Millisecond, 0, false},
+		{1500 * time.Millisecond, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, err := candleInterval(tt.interval)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("candleInterval(%v) = %d, %v, want %d, ok %v", tt.interval, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func candle(at time.Time, open, high, low, close, volume, quoteVolume float64) CandleStick {
+	return CandleStick{
+		Date:        PoloTime{at},
+		Open:        open,
+		High:        high,
+		Low:         low,
+		Close:       close,
+		Volume:      volume,
+		QuoteVolume: quoteVolume,
+	}
+}
+
+func TestResampleCandles(t *testing.T) {
+	hourly := []CandleStick{
+		candle(date(2026, 10, 12, 2), 12, 14, 11, 13, 2, 1),
+		candle(date(2026, 10, 12, 0), 10, 12, 9, 11, 3, 1),
+		candle(date(2026, 10, 12, 1), 11, 13, 8, 12, 1, 1),
+		candle(date(2026, 10, 12, 3), 13, 13, 12, 12, 4, 2),
+	}
+
+	tests := []struct {
+		name     string
+		candles  []CandleStick
+		interval time.Duration
+		want     []CandleStick
+		ok       bool
+	}{
+		{
+			"two hours", hourly, 2 * time.Hour,
+			[]CandleStick{
+				candle(date(2026, 10, 12, 0), 10, 13, 8, 12, 4, 2),
+				candle(date(2026, 10, 12, 2), 12, 14, 11, 12, 6, 3),
+			},
+			true,
+		},
+		{
+			"day", hourly, 24 * time.Hour,
+			[]CandleStick{candle(date(2026, 10, 12, 0), 10, 14, 8, 12, 10, 5)},
+			true,
+		},
+		{
+			"placeholder skipped", append([]CandleStick{{Date: PoloTime{time.Unix(0, 0).UTC()}}}, hourly...), 24 * time.Hour,
+			[]CandleStick{candle(date(2026, 10, 12, 0), 10, 14, 8, 12, 10, 5)},
+			true,
+		},
+		{"not a multiple", hourly, 90 * time.Minute, nil, false},
+		{"finer", hourly, 30 * time.Minute, nil, false},
+		{"empty", nil, time.Hour, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ResampleCandles(tt.candles, tt.interval)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: error = %v, want ok %v", tt.name, err, tt.ok)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %d candles, want %d", tt.name, len(got), len(tt.want))
+			continue
+		}
+		for i := range got {
+			want := tt.want[i]
+			want.WeightedAverage = want.Volume / want.QuoteVolume
+			if got[i] != want {
+				t.Errorf("%s: candle %d = %+v, want %+v", tt.name, i, got[i], want)
+			}
+		}
+	}
+}
+
+func TestCandlesFromTrades(t *testing.T) {
+	trade := func(id uint64, at time.Time, rate, amount string) PublicTrade {
+		r, a := decimal.RequireFromString(rate), decimal.RequireFromString(amount)
+		return PublicTrade{TradeID: id, Date: PoloTime{at}, Rate: r, Amount: a, Total: r.Mul(a)}
+	}
+
+	start := date(2026, 10, 12, 0)
+	trades := []PublicTrade{
+		trade(3, start.Add(90*time.Second), "12", "1"),
+		trade(1, start.Add(10*time.Second), "10", "1"),
+		trade(2, start.Add(10*time.Second), "11", "2"),
+		trade(4, start.Add(200*time.Second), "9", "1"),
+	}
+
+	got, err := CandlesFromTrades(trades, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []CandleStick{
+		candle(start, 10, 11, 10, 11, 32, 3),
+		candle(start.Add(time.Minute), 12, 12, 12, 12, 12, 1),
+		candle(start.Add(3*time.Minute), 9, 9, 9, 9, 9, 1),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d candles, want %d", len(got), len(want))
+	}
+	for i := range got {
+		want[i].WeightedAverage = want[i].Volume / want[i].QuoteVolume
+		if got[i] != want[i] {
+			t.Errorf("candle %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCandleAggregator(t *testing.T) {
+	a, err := NewCandleAggregator(time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := date(2026, 10, 12, 0)
+	a.AddNewTrade(NewTrade{Rate: 10, Amount: 1, Total: 10}, start.Add(time.Second))
+	a.AddNewTrade(NewTrade{Rate: 12, Amount: 1, Total: 12}, start.Add(2*time.Second))
+	a.AddNewTrade(NewTrade{Rate: 8, Amount: 1, Total: 8}, start.Add(61*time.Second))
+	a.Tick(start.Add(2 * time.Minute))
+	a.Close()
+
+	var closed []CandleStick
+	for update := range a.C {
+		if update.Closed {
+			closed = append(closed, update.Candle)
+		}
+	}
+
+	want := []CandleStick{
+		candle(start, 10, 12, 10, 12, 22, 2),
+		candle(start.Add(time.Minute), 8, 8, 8, 8, 8, 1),
+	}
+	if len(closed) != len(want) {
+		t.Fatalf("got %d closed candles, want %d", len(closed), len(want))
+	}
+	for i := range closed {
+		want[i].WeightedAverage = want[i].Volume / want[i].QuoteVolume
+		if closed[i] != want[i] {
+			t.Errorf("closed candle %d = %+v, want %+v", i, closed[i], want[i])
+		}
+	}
+}
+
+func TestCandleAggregatorCloseUnblocksSend(t *testing.T) {
+	a, err := NewCandleAggregator(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// nobody reads, so closed candles fill the channel and block.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		start := time.Unix(1000, 0)
+		for i := 0; i < CANDLEBUFFER*2; i++ {
+			a.AddNewTrade(NewTrade{Rate: 1, Amount: 1, Total: 1}, start.Add(time.Duration(i)*time.Second))
+		}
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	a.Close()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("blocked send was not abandoned by Close")
+	}
+}
@@ -10,14 +10,21 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 const (
-	origin        = "https://api2.poloniex.com/"
-	pushAPIUrl    = "wss://api2.poloniex.com/realm1"
-	publicAPIUrl  = "https://poloniex.com/public?command="
-	tradingAPIUrl = "https://poloniex.com/tradingApi"
+	origin         = "https://api2.poloniex.com/"
+	pushAPIUrl     = "wss://api2.poloniex.com/realm1"
+	publicAPIUrl   = "https://poloniex.com/public?command="
+	tradingAPIUrl  = "https://poloniex.com/tradingApi"
+	ORDERRETRIES   = 3    // Order Placement Attempts
+	ORDERRETRYWAIT = 1    // Order Retry Backoff (seconds)
+	CANCELWORKERS  = 5    // Parallel Order Cancellations
+	MARKETSTTL     = 3600 // Market Metadata Cache Seconds
 )
 
 var (
@@ -25,18 +32,50 @@ var (
 )
 
 type Poloniex struct {
-	key        string
-	secret     string
-	httpClient *http.Client
+	key            string
+	secret         string
+	httpClient     *http.Client
+	precisions     map[string]Precision       // order precision by market
+	minTotals      map[string]decimal.Decimal // minimum order total by base currency
+	precisionMutex sync.RWMutex
+	markets        map[string]Market // market metadata cache
+	marketsUpdated time.Time
+	marketsMutex   sync.RWMutex
+	nonce          int64 // last trading api nonce
+	nonceMutex     sync.Mutex
+	withdrawals    bool // allow withdrawals
+	validation     bool // validate orders before placement
+}
+
+// Client option.
+type ClientOption func(*Poloniex)
+
+// Allow Withdraw calls.
+// Clients created without this option can never withdraw.
+func EnableWithdrawals() ClientOption {
+	return func(p *Poloniex) {
+		p.withdrawals = true
+	}
+}
+
+// Validate Buy and Sell orders against market metadata
+// before sending them, see ValidateOrder.
+func EnableOrderValidation() ClientOption {
+	return func(p *Poloniex) {
+		p.validation = true
+	}
 }
 
-func NewClient(key, secret string) (client *Poloniex, err error) {
+func NewClient(key, secret string, options ...ClientOption) (client *Poloniex, err error) {
 	client = &Poloniex{
 		key:        key,
 		secret:     secret,
 		httpClient: &http.Client{Timeout: time.Second * 10},
 	}
 
+	for _, option := range options {
+		option(client)
+	}
 	return
 }
 
@@ -110,18 +149,15 @@ func checkServerError(response []byte) error {
 	}
 }
 
-// Create trading api request.
-func (p *Poloniex) tradingRequest(action string, parameters map[string]string,
-	respch chan<- []byte, errch chan<- error) {
-
-	defer close(respch)
-	defer close(errch)
-
-	if parameters == nil {
-		parameters = make(map[string]string)
+// Build signed trading api request with the next nonce.
+// It must be called with nonceMutex held.
+func (p *Poloniex) signedRequest(parameters map[string]string) (*http.Request, error) {
+	nonce := time.Now().UnixNano()
+	if nonce <= p.nonce {
+		nonce = p.nonce + 1
 	}
-	parameters["command"] = action
-	parameters["nonce"] = strconv.FormatInt(time.Now().UnixNano(), 10)
+	p.nonce = nonce
+	parameters["nonce"] = strconv.FormatInt(nonce, 10)
 
 	formValues := url.Values{}
 
@@ -133,25 +169,46 @@ func (p *Poloniex) tradingRequest(action string, parameters map[string]string,
 
 	sign, err := p.sign(formData)
 	if err != nil {
-		respch <- nil
-		errch <- err
-		return
+		return nil, err
 	}
 
 	req, err := http.NewRequest("POST", tradingAPIUrl,
 		strings.NewReader(formData))
 	if err != nil {
-		respch <- nil
-		errch <- Error(RequestError)
-		return
+		return nil, Error(RequestError)
 	}
 
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Add("Key", p.key)
 	req.Header.Add("Sign", sign)
+	return req, nil
+}
+
+// Create trading api request.
+func (p *Poloniex) tradingRequest(action string, parameters map[string]string,
+	respch chan<- []byte, errch chan<- error) {
+
+	defer close(respch)
+	defer close(errch)
+
+	if parameters == nil {
+		parameters = make(map[string]string)
+	}
+	parameters["command"] = action
 
+	// nonces are issued after the throttle, in send order.
+	p.nonceMutex.Lock()
 	<-throttle
+	req, err := p.signedRequest(parameters)
+	p.nonceMutex.Unlock()
+
+	if err != nil {
+		respch <- nil
+		errch <- err
+		return
+	}
+
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
 		respch <- nil
@@ -0,0 +1,151 @@
+package poloniex
+
+import (
+	"strings"
+)
+
+// Side of orders and trades.
+// Trades are "buy" or "sell", order book entries "bid" or "ask".
+type Side string
+
+const (
+	SideBuy  Side = "buy"
+	SideSell Side = "sell"
+	SideBid  Side = "bid"
+	SideAsk  Side = "ask"
+)
+
+// It reports whether side is on the buying side of the book.
+func (s Side) IsBuy() bool {
+	return s == SideBuy || s == SideBid
+}
+
+// It reports whether side is on the selling side of the book.
+func (s Side) IsSell() bool {
+	return s == SideSell || s == SideAsk
+}
+
+func (s Side) String() string {
+	return string(s)
+}
+
+func (s Side) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+func (s *Side) UnmarshalText(b []byte) error {
+	side := Side(strings.ToLower(string(b)))
+
+	switch side {
+	case SideBuy, SideSell, SideBid, SideAsk:
+		*s = side
+	case "":
+		*s = ""
+	default:
+		return Error(SideError, string(b))
+	}
+
+	return nil
+}
+
+// Status of orders returned by returnOrderStatus.
+type OrderStatus string
+
+const (
+	OrderOpen            OrderStatus = "Open"
+	OrderPartiallyFilled OrderStatus = "Partially filled"
+	OrderFilled          OrderStatus = "Filled"
+	OrderCancelled       OrderStatus = "Cancelled"
+)
+
+// It reports whether order is still on the book.
+func (o OrderStatus) IsOpen() bool {
+	return o == OrderOpen || o == OrderPartiallyFilled
+}
+
+func (o OrderStatus) String() string {
+	return string(o)
+}
+
+func (o OrderStatus) MarshalText() ([]byte, error) {
+	return []byte(o), nil
+}
+
+// Known statuses are matched case insensitively,
+// unknown ones are kept as they are.
+func (o *OrderStatus) UnmarshalText(b []byte) error {
+	status := OrderStatus(b)
+
+	for _, v := range []OrderStatus{OrderOpen, OrderPartiallyFilled, OrderFilled, OrderCancelled} {
+		if strings.EqualFold(string(status), string(v)) {
+			status = v
+			break
+		}
+	}
+
+	*o = status
+	return nil
+}
+
+// Kind of market updates received from push api.
+type UpdateKind string
+
+const (
+	OrderDepthUpdate      UpdateKind = "OrderDepth"
+	OrderBookModifyUpdate UpdateKind = "OrderBookModify"
+	OrderBookRemoveUpdate UpdateKind = "OrderBookRemove"
+	NewTradeUpdate        UpdateKind = "NewTrade"
+)
+
+func (u UpdateKind) String() string {
+	return string(u)
+}
+
+func (u UpdateKind) MarshalText() ([]byte, error) {
+	return []byte(u), nil
+}
+
+func (u *UpdateKind) UnmarshalText(b []byte) error {
+	kind := UpdateKind(b)
+
+	switch kind {
+	case OrderDepthUpdate, OrderBookModifyUpdate, OrderBookRemoveUpdate, NewTradeUpdate:
+		*u = kind
+	default:
+		return Error(UpdateKindError, string(b))
+	}
+
+	return nil
+}
+
+// Account type, one for each field of Accounts.
+type AccountType string
+
+const (
+	ExchangeAccount AccountType = "exchange"
+	MarginAccount   AccountType = "margin"
+	LendingAccount  AccountType = "lending"
+)
+
+// It reports whether account type is known.
+func (a AccountType) valid() bool {
+	return a == ExchangeAccount || a == MarginAccount || a == LendingAccount
+}
+
+func (a AccountType) String() string {
+	return string(a)
+}
+
+func (a AccountType) MarshalText() ([]byte, error) {
+	return []byte(a), nil
+}
+
+func (a *AccountType) UnmarshalText(b []byte) error {
+	account := AccountType(strings.ToLower(string(b)))
+	if !account.valid() {
+		return Error(AccountError, string(b))
+	}
+
+	*a = account
+	return nil
+}
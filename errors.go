@@ -6,26 +6,57 @@ import (
 )
 
 var (
-	ConnectError     = "[ERROR] Connection could not be established!"
-	RequestError     = "[ERROR] NewRequest Error!"
-	SetApiError      = "[ERROR] Set the API KEY and API SECRET!"
-	PeriodError      = "[ERROR] Invalid Period!"
-	TimePeriodError  = "[ERROR] Time Period incompatibility!"
-	TimeError        = "[ERROR] Invalid Time!"
-	StartTimeError   = "[ERROR] Start Time Format Error!"
-	EndTimeError     = "[ERROR] End Time Format Error!"
-	LimitError       = "[ERROR] Limit Format Error!"
-	ChannelError     = "[ERROR] Unknown Channel Name: %s"
-	SubscribeError   = "[ERROR] Already Subscribed!"
-	WSTickerError    = "[ERROR] WSTicker Parsing %s"
-	WSOrderBookError = "[ERROR] MarketUpdate OrderBook Parsing %s"
-	NewTradeError    = "[ERROR] MarketUpdate NewTrade Parsing %s"
-	ServerError      = "[SERVER ERROR] Response: %s"
+	ConnectError             = "[ERROR] Connection could not be established!"
+	RequestError             = "[ERROR] NewRequest Error!"
+	SetApiError              = "[ERROR] Set the API KEY and API SECRET!"
+	PeriodError              = "[ERROR] Invalid Period!"
+	TimePeriodError          = "[ERROR] Time Period incompatibility!"
+	TimeError                = "[ERROR] Invalid Time!"
+	StartTimeError           = "[ERROR] Start Time Format Error!"
+	EndTimeError             = "[ERROR] End Time Format Error!"
+	LimitError               = "[ERROR] Limit Format Error!"
+	ChannelError             = "[ERROR] Unknown Channel Name: %s"
+	ChannelsError            = "[ERROR] Unknown Channel Names: %s"
+	SubscribeError           = "[ERROR] Already Subscribed!"
+	UnsubscribeError         = "[ERROR] Unsubscription Not Acknowledged: %s"
+	PoolSizeError            = "[ERROR] Pool Size Must Be Positive!"
+	PoolConnectError         = "[ERROR] No Connected Client In Pool!"
+	PoolClosedError          = "[ERROR] Pool Is Closed!"
+	WSClosedError            = "[ERROR] Web Socket Client Is Closed!"
+	WSTickerError            = "[ERROR] WSTicker Parsing %s"
+	WSOrderBookError         = "[ERROR] MarketUpdate OrderBook Parsing %s"
+	OrderDepthError          = "[ERROR] MarketUpdate OrderDepth Parsing %s"
+	NewTradeError            = "[ERROR] MarketUpdate NewTrade Parsing %s"
+	TradesTruncatedError     = "[ERROR] Public Trades Truncated At %s"
+	MarketUpdateError        = "[ERROR] MarketUpdate Parsing %s"
+	WSUpdateError            = "[ERROR] Update Of Channel %s"
+	OrderOptionsError        = "[ERROR] Only One Order Options Value Is Accepted!"
+	OrderFlagsError          = "[ERROR] Only One Of FillOrKill, ImmediateOrCancel And PostOnly Can Be Set!"
+	OrderPriceError          = "[ERROR] Order Price Must Be Positive!"
+	OrderPricePrecisionError = "[ERROR] Order Price Exceeds Precision: %s"
+	OrderAmountError         = "[ERROR] Order Amount Must Be Positive!"
+	LoanRateError            = "[ERROR] Lending Rate Must Be Positive!"
+	LendingHistoryError      = "[ERROR] Lending History Page Closed Within One Second: %s"
+	SideError                = "[ERROR] Unknown Order Side: %s"
+	UpdateKindError          = "[ERROR] Unknown Update Kind: %s"
+	PairError                = "[ERROR] Invalid Currency Pair: %s"
+	UnknownPairError         = "[ERROR] Unknown Currency Pair: %s"
+	MarketTradableError      = "[ERROR] Market Is Not Tradable: %s"
+	OrderTotalError          = "[ERROR] Order Total Below Minimum: %s"
+	CandleIntervalError      = "[ERROR] Candle Interval Must Be Whole Seconds: %s"
+	ResampleIntervalError    = "[ERROR] Resample Interval Must Be A Multiple Of The Candle Interval: %s"
+	AccountError             = "[ERROR] Invalid Account Transfer: %s"
+	WithdrawError            = "[ERROR] Withdrawals Are Not Enabled For This Client!"
+	MoveFlagsError           = "[ERROR] FillOrKill Is Not Supported By MoveOrder!"
+	CancelOrdersError        = "[ERROR] Orders Could Not Be Cancelled: %s"
+	ClientOrderError         = "[ERROR] Unknown Client Order Id: %s"
+	ClientOrderIDError       = "[ERROR] Client Order Id Is Required!"
+	ServerError              = "[SERVER ERROR] Response: %s"
 )
 
 func Error(msg string, args ...interface{}) error {
 	if len(args) > 0 {
-		return errors.New(fmt.Sprintf(msg, args))
+		return errors.New(fmt.Sprintf(msg, args...))
 	} else {
 		return errors.New(msg)
 	}
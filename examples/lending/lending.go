@@ -0,0 +1,53 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	polo "github.com/iowar/poloniex"
+	"github.com/iowar/poloniex/lending"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	api_key    = ""
+	api_secret = ""
+)
+
+func main() {
+	poloniex, err := polo.NewClient(api_key, api_secret)
+	if err != nil {
+		return
+	}
+
+	bot := lending.New(poloniex, lending.Config{
+		Currencies: []string{"BTC", "ETH"},
+		Strategy:   lending.Ladder{Steps: 3, Step: decimal.New(1, -5)},
+		MinRate:    decimal.New(1, -4),
+		Durations: []lending.DurationRule{
+			{Rate: decimal.New(5, -4), Days: 30},
+			{Rate: decimal.New(1, -3), Days: 60},
+		},
+		Interval:   time.Minute * 5,
+		StaleAfter: time.Hour,
+		DryRun:     true,
+		Logger:     log.New(os.Stdout, "", log.LstdFlags),
+	})
+
+	go func() {
+		for range time.Tick(time.Hour) {
+			yields, err := bot.Yield(time.Now().AddDate(0, 0, -30))
+			if err != nil {
+				log.Print(err)
+				continue
+			}
+			for _, y := range yields {
+				log.Printf("%s earned:%s rate:%s active:%s",
+					y.Currency, y.Earned, y.AverageRate, y.Active)
+			}
+		}
+	}()
+
+	bot.Run(make(chan struct{}))
+}
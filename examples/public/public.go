@@ -19,6 +19,7 @@ func main() {
 	//resp, err := poloniex.GetOrderBook("btc_dgb", 1)
 	//resp, err := poloniex.GetPublicTradeHistory("btc_dgb")
 	//resp, err := poloniex.GetPublicTradeHistory("btc_sc", time.Now().AddDate(0, 0, -1), time.Now())
+	//resp, err := poloniex.GetPublicTradeRange("btc_sc", time.Now().AddDate(0, 0, -7), time.Now())
 	//resp, err := poloniex.GetChartData("btc_dgb", time.Now().AddDate(0, 0, -1), time.Now(), "1d")
 	//resp, err := poloniex.GetCurrencies()
 	//resp, err := poloniex.GetLoanOrders("BTC")
@@ -0,0 +1,27 @@
+//the following code shows
+//how to read several markets from one stream.
+package main
+
+import (
+	"fmt"
+
+	polo "github.com/iowar/poloniex"
+)
+
+func main() {
+
+	ws, err := polo.NewWSClient()
+	if err != nil {
+		return
+	}
+
+	stream, err := ws.SubscribeMarkets("USDT_BTC", "USDT_ETH", "BTC_XMR")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for {
+		update := <-stream
+		fmt.Println(update.Pair, update.Updates)
+	}
+}
@@ -26,7 +26,7 @@ func main() {
 		receive := <-ws.Subs["USDT_BTC"]
 		updates := receive.([]polo.MarketUpdate)
 		for _, v := range updates {
-			if v.TypeUpdate == "NewTrade" {
+			if v.TypeUpdate == polo.NewTradeUpdate {
 				n = v.Data.(polo.NewTrade)
 				fmt.Printf("TradeId:%d, Rate:%f, Amount:%f, Total:%f, Type:%s\n",
 					n.TradeId, n.Rate, n.Amount, n.Total, n.TypeOrder)
@@ -26,7 +26,7 @@ func main() {
 		receive := <-ws.Subs["USDT_BTC"]
 		updates := receive.([]polo.MarketUpdate)
 		for _, v := range updates {
-			if v.TypeUpdate == "OrderBookRemove" || v.TypeUpdate == "OrderBookModify" {
+			if v.TypeUpdate == polo.OrderBookRemoveUpdate || v.TypeUpdate == polo.OrderBookModifyUpdate {
 				m = v.Data.(polo.WSOrderBook)
 
 				fmt.Printf("Rate:%f, Type:%s, Amount:%f\n",
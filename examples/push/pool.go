@@ -0,0 +1,36 @@
+//the following code shows
+//how to spread markets over several connections.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	polo "github.com/iowar/poloniex"
+)
+
+func main() {
+
+	pool, err := polo.NewWSPool(4)
+	if err != nil {
+		return
+	}
+
+	stream, err := pool.SubscribeAllMarkets()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	go func() {
+		for range time.Tick(time.Minute) {
+			status := pool.Status()
+			fmt.Printf("Healthy:%t, Connected:%d/%d, Channels:%d\n",
+				status.Healthy(), status.Connected, status.Connections, status.Channels)
+		}
+	}()
+
+	for {
+		update := <-stream
+		fmt.Println(update.Pair, update.Updates)
+	}
+}
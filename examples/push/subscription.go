@@ -0,0 +1,36 @@
+//the following code shows
+//how to use subscription handles.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	polo "github.com/iowar/poloniex"
+)
+
+func main() {
+
+	ws, err := polo.NewWSClient()
+	if err != nil {
+		return
+	}
+
+	sub, err := ws.Subscribe("USDT_BTC")
+	if err != nil {
+		return
+	}
+
+	go func() {
+		time.Sleep(time.Second * 10)
+		err := sub.Unsubscribe()
+		if err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	// the loop ends when the handle is unsubscribed.
+	for update := range sub.C {
+		fmt.Println(update)
+	}
+}
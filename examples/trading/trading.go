@@ -19,14 +19,28 @@ func main() {
 	//resp, err := poloniex.GetAccountBalances()
 	//resp, err := poloniex.GetDepositAddresses()
 	//resp, err := poloniex.GenerateNewAddress("USDT")
+	//resp, err := poloniex.GetDepositsWithdrawals(time.Now().AddDate(0, -1, 0), time.Now())
 	//resp, err := poloniex.GetOpenOrders("btc_dgb")
 	//resp, err := poloniex.GetAllOpenOrders()
 	//resp, err := poloniex.CancelOrder("36121803064")
 	//resp, err := poloniex.GetTradeHistory("btc_eth", time.Now().AddDate(0, 0, -600), time.Now(), 1)
 	//resp, err := poloniex.GetTradesByOrderID("414366201166")
 	//resp, err := poloniex.GetOrderStat("36121689178")
+	//resp, err := poloniex.GetFeeInfo()
 	//resp, err := poloniex.Buy("btc_dgb", 0.00000001, 23000)
 	//resp, err := poloniex.Sell("btc_dgb", 1, 23.1)
+	//resp, err := poloniex.Buy("btc_dgb", 0.00000001, 23000, polo.OrderOptions{PostOnly: true})
+	//resp, err := poloniex.Sell("btc_dgb", 1, 23.1, polo.OrderOptions{ImmediateOrCancel: true})
+	//resp, err := poloniex.MoveOrderDecimal("btc_dgb", "36121803064", decimal.New(2, -8), decimal.Zero)
+	//resp, err := poloniex.GetMarginPosition("btc_eth")
+	//resp, err := poloniex.CloseMarginPosition("btc_eth")
+	//resp, err := poloniex.GetMarginAccountSummary()
+	//resp, err := poloniex.GetTradableBalances()
+	//resp, err := poloniex.GetOpenLoanOffers()
+	//resp, err := poloniex.GetActiveLoans()
+	//resp, err := poloniex.GetLendingHistory(time.Now().AddDate(0, -1, 0), time.Now(), 100)
+	//resp, err := poloniex.CancelLoanOffer(10590)
+	//resp, err := poloniex.ToggleAutoRenew(75073)
 
 	if err != nil {
 		fmt.Println(err)
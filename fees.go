@@ -0,0 +1,68 @@
+package poloniex
+
+import (
+	"encoding/json"
+
+	"github.com/shopspring/decimal"
+)
+
+type FeeInfo struct {
+	MakerFee        decimal.Decimal `json:"makerFee"`
+	TakerFee        decimal.Decimal `json:"takerFee"`
+	ThirtyDayVolume decimal.Decimal `json:"thirtyDayVolume"`
+	NextTier        decimal.Decimal `json:"nextTier"`
+}
+
+// Get maker and taker fee rates of the account.
+func (p *Poloniex) GetFeeInfo() (feeinfo FeeInfo, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("returnFeeInfo", nil, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &feeinfo)
+	return
+}
+
+var one = decimal.New(1, 0)
+
+// Get fee rate of maker or taker orders.
+func (f FeeInfo) Rate(maker bool) decimal.Decimal {
+	if maker {
+		return f.MakerFee
+	}
+	return f.TakerFee
+}
+
+// Get amount received by buying amount.
+// Buy fees are taken from the bought currency.
+func (f FeeInfo) NetBuy(amount decimal.Decimal, maker bool) decimal.Decimal {
+	return amount.Mul(one.Sub(f.Rate(maker)))
+}
+
+// Get total received by selling amount at price.
+// Sell fees are taken from the base currency.
+func (f FeeInfo) NetSell(price, amount decimal.Decimal, maker bool) decimal.Decimal {
+	return price.Mul(amount).Mul(one.Sub(f.Rate(maker)))
+}
+
+// Get the sell price that returns the total spent buying at price.
+func (f FeeInfo) BreakEven(price decimal.Decimal, buyMaker, sellMaker bool) decimal.Decimal {
+	kept := one.Sub(f.Rate(buyMaker)).Mul(one.Sub(f.Rate(sellMaker)))
+	return price.DivRound(kept, 8)
+}
+
+// Get profit or loss in base currency of buying amount at buyPrice
+// and selling everything received at sellPrice, after fees.
+func (f FeeInfo) ProfitLoss(buyPrice, sellPrice, amount decimal.Decimal, buyMaker, sellMaker bool) decimal.Decimal {
+	spent := buyPrice.Mul(amount)
+	received := f.NetSell(sellPrice, f.NetBuy(amount, buyMaker), sellMaker)
+	return received.Sub(spent)
+}
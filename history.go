@@ -0,0 +1,365 @@
+package poloniex
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	TRADEPAGE       = 10000 // Largest Trade History Page
+	PUBLICTRADEPAGE = 1000  // Largest Public Trade History Page
+	CHARTPAGE       = 1000  // Candles Per Chart Data Request
+)
+
+// Iterator over private trade history.
+// Trades are returned from the newest to the oldest one, time
+// windows are walked backwards until the start time is reached.
+type TradeHistoryIterator struct {
+	client  *Poloniex
+	market  string
+	start   time.Time
+	end     time.Time
+	seen    map[int]bool // trades at the end of the window
+	page    []TradeHistory
+	current TradeHistory
+	done    bool
+	err     error
+}
+
+// Create trade history iterator of market between start and end.
+// Market "all" iterates over every market.
+func (p *Poloniex) NewTradeHistoryIterator(market string, start, end time.Time) *TradeHistoryIterator {
+	return &TradeHistoryIterator{
+		client: p,
+		market: strings.ToUpper(market),
+		start:  start,
+		end:    end,
+		seen:   make(map[int]bool),
+	}
+}
+
+// Advance to the next trade.
+// It returns false when the history is exhausted or an error occurs.
+func (it *TradeHistoryIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.err = it.fetch()
+	}
+
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Get the current trade.
+func (it *TradeHistoryIterator) Trade() TradeHistory {
+	return it.current
+}
+
+// Get the error that stopped the iteration.
+func (it *TradeHistoryIterator) Err() error {
+	return it.err
+}
+
+// Fetch the next window.
+// Trades seen at the end of the previous window are skipped.
+func (it *TradeHistoryIterator) fetch() error {
+	var trades []TradeHistory
+
+	if it.market == "ALL" {
+		all, err := it.client.GetAllTradeHistory(it.start, it.end, TRADEPAGE)
+		if err != nil {
+			return err
+		}
+		for _, v := range all {
+			trades = append(trades, v...)
+		}
+	} else {
+		var err error
+		trades, err = it.client.GetTradeHistory(it.market, it.start, it.end, TRADEPAGE)
+		if err != nil {
+			return err
+		}
+		for i := range trades {
+			trades[i].Market = it.market
+		}
+	}
+
+	if len(trades) < TRADEPAGE {
+		it.done = true
+	}
+
+	var oldest time.Time
+	for _, v := range trades {
+		if oldest.IsZero() || v.Date.Before(oldest) {
+			oldest = v.Date.Time
+		}
+	}
+
+	for _, v := range trades {
+		if !it.seen[v.GlobalTradeID] {
+			it.page = append(it.page, v)
+		}
+	}
+	sortTradeHistory(it.page)
+
+	if it.done {
+		return nil
+	}
+
+	// the next window ends at the oldest trade, trades of that
+	// second are fetched again and skipped as seen.
+	if !oldest.Before(it.end) {
+		oldest = it.end.Add(-time.Second)
+	}
+	it.end = oldest
+
+	it.seen = make(map[int]bool)
+	for _, v := range trades {
+		if !v.Date.Before(oldest) {
+			it.seen[v.GlobalTradeID] = true
+		}
+	}
+
+	if it.end.Before(it.start) {
+		it.done = true
+	}
+	return nil
+}
+
+// Sort trades from the newest to the oldest one.
+func sortTradeHistory(trades []TradeHistory) {
+	sort.Slice(trades, func(i, j int) bool {
+		return trades[i].GlobalTradeID > trades[j].GlobalTradeID
+	})
+}
+
+// Stream public trades of market between start and end in chronological order.
+// Windows hitting the page size of the exchange are split in half until
+// they fit, so busy windows are not truncated. One second windows that
+// still fill a page are sent, the stream goes on and TradesTruncatedError
+// is reported with their dates once the range is done.
+// Trades are de-duplicated by trade id. The trade channel is closed when
+// the range is exhausted or stop is closed, the error channel receives at
+// most one error and is closed after it. stop may be nil.
+func (p *Poloniex) StreamPublicTrades(market string, start, end time.Time, stop <-chan struct{}) (<-chan PublicTrade, <-chan error) {
+	tradech := make(chan PublicTrade, PUBLICTRADEPAGE)
+	errch := make(chan error, 1)
+
+	go func() {
+		defer close(errch)
+		defer close(tradech)
+
+		send := func(v PublicTrade) error {
+			select {
+			case tradech <- v:
+				return nil
+			case <-stop:
+				return errStopped
+			}
+		}
+
+		var last uint64
+		var truncated []int64
+		err := p.streamPublicTrades(market, start.Unix(), end.Unix(), &last, &truncated, send, stop)
+		if err == nil && len(truncated) > 0 {
+			err = truncatedError(truncated)
+		}
+		if err != nil && err != errStopped {
+			errch <- err
+		}
+	}()
+
+	return tradech, errch
+}
+
+// Stop of streams, it is not reported to the consumer.
+var errStopped = errors.New("stopped")
+
+// sub-function for streaming public trades of window [start, end] in seconds.
+// last is the id of the last sent trade, the starts of truncated one second
+// windows are added to truncated.
+func (p *Poloniex) streamPublicTrades(market string, start, end int64, last *uint64, truncated *[]int64, send func(PublicTrade) error, stop <-chan struct{}) error {
+	select {
+	case <-stop:
+		return errStopped
+	default:
+	}
+
+	trades, err := p.GetPublicTradeHistory(market, time.Unix(start, 0), time.Unix(end, 0))
+	if err != nil {
+		return err
+	}
+
+	if len(trades) >= PUBLICTRADEPAGE && end > start {
+		mid := start + (end-start)/2
+		err = p.streamPublicTrades(market, start, mid, last, truncated, send, stop)
+		if err != nil {
+			return err
+		}
+		return p.streamPublicTrades(market, mid+1, end, last, truncated, send, stop)
+	}
+
+	sort.Slice(trades, func(i, j int) bool {
+		return trades[i].TradeID < trades[j].TradeID
+	})
+
+	for _, v := range trades {
+		if v.TradeID <= *last {
+			continue
+		}
+		*last = v.TradeID
+
+		err = send(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	// a window of one second can not be split further.
+	if len(trades) >= PUBLICTRADEPAGE {
+		*truncated = append(*truncated, start)
+	}
+	return nil
+}
+
+// Get TradesTruncatedError of the truncated one second windows.
+func truncatedError(truncated []int64) error {
+	dates := make([]string, len(truncated))
+	for i, v := range truncated {
+		dates[i] = time.Unix(v, 0).UTC().Format(DateLayout)
+	}
+	return Error(TradesTruncatedError, strings.Join(dates, ", "))
+}
+
+// Get public trades of market between start and end in chronological order.
+// See StreamPublicTrades.
+func (p *Poloniex) GetPublicTradeRange(market string, start, end time.Time) (trades []PublicTrade, err error) {
+	tradech, errch := p.StreamPublicTrades(market, start, end, nil)
+	for v := range tradech {
+		trades = append(trades, v)
+	}
+	return trades, <-errch
+}
+
+// Missing candles of chart data in [Start, End).
+type ChartGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Get candles of market between start and end as one continuous series.
+// Long ranges are fetched in windows of CHARTPAGE candles, candles are
+// de-duplicated by date and missing candles are reported as gaps.
+func (p *Poloniex) GetChartRange(market string, start, end time.Time, period Period) (candles []CandleStick, gaps []ChartGap, err error) {
+	if !period.valid() {
+		err = Error(PeriodError)
+		return
+	}
+
+	if now := time.Now(); end.After(now) {
+		end = now
+	}
+
+	if start.IsZero() || end.IsZero() || !start.Before(end) {
+		err = Error(TimeError)
+		return
+	}
+
+	step := int64(period)
+
+	// candles are dated at the start of their period.
+	first := start.Unix()
+	if r := first % step; r != 0 {
+		first += step - r
+	}
+	last := end.Unix() - end.Unix()%step
+
+	candles, err = p.chartRange(market, first, last, period)
+	if err != nil {
+		return
+	}
+
+	gaps = chartGaps(candles, first, last, step)
+	return
+}
+
+// sub-function for candles dated in [first, last] in seconds,
+// both aligned to period.
+func (p *Poloniex) chartRange(market string, first, last int64, period Period) (candles []CandleStick, err error) {
+	step := int64(period)
+
+	for from := first; from <= last; from += step * CHARTPAGE {
+		to := from + step*(CHARTPAGE-1)
+		if to > last {
+			to = last
+		}
+
+		var page []CandleStick
+		page, err = p.getChartData(market, from, to, period)
+		if err != nil {
+			return
+		}
+
+		for _, v := range page {
+			date := v.Date.Unix()
+
+			// empty windows are returned as one candle dated zero.
+			if date < from || date > to {
+				continue
+			}
+			if n := len(candles); n > 0 && date <= candles[n-1].Date.Unix() {
+				continue
+			}
+			candles = append(candles, v)
+		}
+	}
+	return
+}
+
+// sub-function for missing candles of series [first, last] in seconds.
+func chartGaps(candles []CandleStick, first, last, step int64) (gaps []ChartGap) {
+	expected := first
+	for _, v := range candles {
+		date := v.Date.Unix()
+		if date > expected {
+			gaps = append(gaps, ChartGap{
+				Start: time.Unix(expected, 0).UTC(),
+				End:   time.Unix(date, 0).UTC(),
+			})
+		}
+		expected = date + step
+	}
+
+	if expected <= last {
+		gaps = append(gaps, ChartGap{
+			Start: time.Unix(expected, 0).UTC(),
+			End:   time.Unix(last+step, 0).UTC(),
+		})
+	}
+	return
+}
+
+// Missing public trades.
+// Holes between trade ids are [From, To], one second windows cut at the
+// page size of the exchange are reported with Truncated set to their date.
+type TradeGap struct {
+	From      uint64
+	To        uint64
+	Truncated time.Time
+}
+
+// Get the holes between sequential trade ids of sorted trades.
+func tradeGaps(trades []PublicTrade) (gaps []TradeGap) {
+	for i := 1; i < len(trades); i++ {
+		prev, id := trades[i-1].TradeID, trades[i].TradeID
+		if id > prev+1 {
+			gaps = append(gaps, TradeGap{From: prev + 1, To: id - 1})
+		}
+	}
+	return
+}
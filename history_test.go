@@ -0,0 +1,67 @@
+package poloniex
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChartGaps(t *testing.T) {
+	candles := func(dates ...int64) (candles []CandleStick) {
+		for _, v := range dates {
+			candles = append(candles, CandleStick{Date: PoloTime{time.Unix(v, 0).UTC()}})
+		}
+		return
+	}
+	gap := func(start, end int64) ChartGap {
+		return ChartGap{Start: time.Unix(start, 0).UTC(), End: time.Unix(end, 0).UTC()}
+	}
+
+	tests := []struct {
+		name        string
+		candles     []CandleStick
+		first, last int64
+		want        []ChartGap
+	}{
+		{"complete", candles(0, 300, 600), 0, 600, nil},
+		{"empty", nil, 0, 600, []ChartGap{gap(0, 900)}},
+		{"head missing", candles(600), 0, 600, []ChartGap{gap(0, 600)}},
+		{"tail missing", candles(0), 0, 600, []ChartGap{gap(300, 900)}},
+		{"middle missing", candles(0, 900), 0, 900, []ChartGap{gap(300, 900)}},
+		{"several", candles(300, 900), 0, 1200, []ChartGap{gap(0, 300), gap(600, 900), gap(1200, 1500)}},
+	}
+
+	for _, tt := range tests {
+		got := chartGaps(tt.candles, tt.first, tt.last, 300)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: chartGaps = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTradeGaps(t *testing.T) {
+	trades := func(ids ...uint64) (trades []PublicTrade) {
+		for _, v := range ids {
+			trades = append(trades, PublicTrade{TradeID: v})
+		}
+		return
+	}
+
+	tests := []struct {
+		name   string
+		trades []PublicTrade
+		want   []TradeGap
+	}{
+		{"empty", nil, nil},
+		{"single", trades(5), nil},
+		{"sequential", trades(1, 2, 3), nil},
+		{"one missing", trades(1, 3), []TradeGap{{From: 2, To: 2}}},
+		{"several missing", trades(1, 2, 6, 7, 9), []TradeGap{{From: 3, To: 5}, {From: 8, To: 8}}},
+	}
+
+	for _, tt := range tests {
+		if got := tradeGaps(tt.trades); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: tradeGaps = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package poloniex
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type NewLoanOffer struct {
+	Success int    `json:"success"`
+	Message string `json:"message"`
+	OrderID int64  `json:"orderID"`
+}
+
+// Create loan offer.
+// rate is the daily lending rate and duration is in days.
+func (p *Poloniex) CreateLoanOffer(currency string, amount, rate decimal.Decimal, duration int, autoRenew bool) (newloanoffer NewLoanOffer, err error) {
+	amount = amount.Truncate(8)
+	if !amount.IsPositive() {
+		err = Error(OrderAmountError)
+		return
+	}
+
+	if !rate.IsPositive() {
+		err = Error(LoanRateError)
+		return
+	}
+
+	renew := "0"
+	if autoRenew {
+		renew = "1"
+	}
+
+	parameters := map[string]string{
+		"currency":    strings.ToUpper(currency),
+		"amount":      amount.StringFixed(8),
+		"lendingRate": rate.String(),
+		"duration":    strconv.Itoa(duration),
+		"autoRenew":   renew,
+	}
+
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("createLoanOffer", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &newloanoffer)
+	return
+}
+
+type CancelLoanOffer struct {
+	Success int    `json:"success"`
+	Message string `json:"message"`
+}
+
+func (p *Poloniex) CancelLoanOffer(orderID int64) (cancelloanoffer CancelLoanOffer, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	parameters := map[string]string{"orderNumber": strconv.FormatInt(orderID, 10)}
+	go p.tradingRequest("cancelLoanOffer", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &cancelloanoffer)
+	return
+}
+
+type LoanOffer struct {
+	ID        int64           `json:"id"`
+	Rate      decimal.Decimal `json:"rate"`
+	Amount    decimal.Decimal `json:"amount"`
+	Duration  int             `json:"duration"`
+	AutoRenew int             `json:"autoRenew"`
+	Date      PoloTime        `json:"date"`
+}
+
+// Get open loan offers by currency.
+func (p *Poloniex) GetOpenLoanOffers() (loanoffers map[string][]LoanOffer, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("returnOpenLoanOffers", nil, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	// no open offer is returned as an empty list.
+	if strings.TrimSpace(string(resp)) == "[]" {
+		return make(map[string][]LoanOffer), nil
+	}
+
+	err = json.Unmarshal(resp, &loanoffers)
+	return
+}
+
+type ActiveLoan struct {
+	ID        int64           `json:"id"`
+	Currency  string          `json:"currency"`
+	Rate      decimal.Decimal `json:"rate"`
+	Amount    decimal.Decimal `json:"amount"`
+	Range     int             `json:"range"`
+	AutoRenew int             `json:"autoRenew"`
+	Date      PoloTime        `json:"date"`
+	Fees      decimal.Decimal `json:"fees"`
+}
+
+type ActiveLoans struct {
+	Provided []ActiveLoan `json:"provided"`
+	Used     []ActiveLoan `json:"used"`
+}
+
+func (p *Poloniex) GetActiveLoans() (activeloans ActiveLoans, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("returnActiveLoans", nil, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &activeloans)
+	return
+}
+
+type LendingHistory struct {
+	ID       int64           `json:"id"`
+	Currency string          `json:"currency"`
+	Rate     decimal.Decimal `json:"rate"`
+	Amount   decimal.Decimal `json:"amount"`
+	Duration decimal.Decimal `json:"duration"`
+	Interest decimal.Decimal `json:"interest"`
+	Fee      decimal.Decimal `json:"fee"`
+	Earned   decimal.Decimal `json:"earned"`
+	Open     PoloTime        `json:"open"`
+	Close    PoloTime        `json:"close"`
+}
+
+// Get closed loans between start and end.
+func (p *Poloniex) GetLendingHistory(start, end time.Time, limit int) (lendinghistory []LendingHistory, err error) {
+	parameters := map[string]string{
+		"start": strconv.FormatInt(start.Unix(), 10),
+		"end":   strconv.FormatInt(end.Unix(), 10),
+	}
+
+	if limit > 0 {
+		parameters["limit"] = strconv.Itoa(limit)
+	}
+
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("returnLendingHistory", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &lendinghistory)
+	return
+}
+
+// Toggle auto renew of active loan.
+// It returns the new auto renew status.
+func (p *Poloniex) ToggleAutoRenew(orderID int64) (autoRenew bool, err error) {
+	var toggle struct {
+		Success int         `json:"success"`
+		Message json.Number `json:"message"`
+	}
+
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	parameters := map[string]string{"orderNumber": strconv.FormatInt(orderID, 10)}
+	go p.tradingRequest("toggleAutoRenew", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &toggle)
+	if err != nil {
+		return
+	}
+
+	return toggle.Message == "1", nil
+}
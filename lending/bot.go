@@ -0,0 +1,201 @@
+// Package lending re-offers idle lending balances on Poloniex.
+package lending
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	polo "github.com/iowar/poloniex"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DEFAULTDURATION = 2    // Loan Duration (days)
+	LENDINGPAGE     = 1000 // Lending History Page Size
+)
+
+var (
+	// Smallest loan offer accepted by the exchange.
+	DefaultMinAmount = decimal.New(1, -2)
+)
+
+type Config struct {
+	Currencies []string        // currencies to lend, all lending balances if empty
+	Strategy   Strategy        // offer planning, MatchLowest if nil
+	MinRate    decimal.Decimal // rate floor, lower offers are raised to it
+	MinAmount  decimal.Decimal // smallest offer, DefaultMinAmount if zero
+	Durations  []DurationRule  // duration by rate, DEFAULTDURATION days if none matches
+	AutoRenew  bool            // renew loans automatically
+	Interval   time.Duration   // time between runs
+	StaleAfter time.Duration   // open offers older than this are cancelled, zero keeps them
+	DryRun     bool            // plan and report without placing or cancelling
+	Logger     *log.Logger     // optional logger for Run
+}
+
+// Actions of one run.
+type Report struct {
+	Time      time.Time `json:"time"`
+	Cancelled []int64   `json:"cancelled"`
+	Offered   []Offer   `json:"offered"`
+	DryRun    bool      `json:"dryRun"`
+}
+
+type Bot struct {
+	client *polo.Poloniex
+	config Config
+}
+
+// Create new lending bot.
+func New(client *polo.Poloniex, config Config) *Bot {
+	if config.Strategy == nil {
+		config.Strategy = MatchLowest{}
+	}
+	if !config.MinAmount.IsPositive() {
+		config.MinAmount = DefaultMinAmount
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+
+	// balances are keyed by upper case currency names.
+	currencies := make([]string, len(config.Currencies))
+	for i, currency := range config.Currencies {
+		currencies[i] = strings.ToUpper(strings.TrimSpace(currency))
+	}
+	config.Currencies = currencies
+
+	return &Bot{client: client, config: config}
+}
+
+// Run the bot every interval until stop is closed.
+// Errors are logged and the next run is tried.
+func (b *Bot) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(b.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := b.Step()
+		if err != nil {
+			b.logf("lending: %v", err)
+		} else {
+			for _, v := range report.Cancelled {
+				b.logf("lending: cancelled offer %d (dry run: %t)", v, report.DryRun)
+			}
+			for _, v := range report.Offered {
+				b.logf("lending: offered %s %s at %s for %d days (dry run: %t)",
+					v.Amount, v.Currency, v.Rate, v.Duration, report.DryRun)
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *Bot) logf(format string, args ...interface{}) {
+	if b.config.Logger != nil {
+		b.config.Logger.Printf(format, args...)
+	}
+}
+
+// Run the bot once.
+// Stale offers are cancelled first, then idle balances are offered.
+func (b *Bot) Step() (report Report, err error) {
+	report.Time = time.Now()
+	report.DryRun = b.config.DryRun
+
+	report.Cancelled, err = b.cancelStale()
+	if err != nil {
+		return
+	}
+
+	accounts, err := b.client.GetAccountBalances()
+	if err != nil {
+		return
+	}
+
+	currencies := b.config.Currencies
+	if len(currencies) == 0 {
+		for k := range accounts.Lending {
+			currencies = append(currencies, k)
+		}
+	}
+
+	for _, currency := range currencies {
+		balance := accounts.Lending[currency]
+		if balance.LessThan(b.config.MinAmount) {
+			continue
+		}
+
+		book, err := b.client.GetLoanOrders(currency)
+		if err != nil {
+			return report, err
+		}
+
+		for _, offer := range b.plan(currency, balance, book) {
+			if !b.config.DryRun {
+				_, err = b.client.CreateLoanOffer(offer.Currency, offer.Amount,
+					offer.Rate, offer.Duration, b.config.AutoRenew)
+				if err != nil {
+					return report, err
+				}
+			}
+			report.Offered = append(report.Offered, offer)
+		}
+	}
+	return
+}
+
+// Plan offers for idle balance of currency.
+// The rate floor and duration rules are applied to the strategy offers,
+// and offers smaller than the minimum amount are dropped.
+func (b *Bot) plan(currency string, balance decimal.Decimal, book polo.LoanOrder) (offers []Offer) {
+	for _, offer := range b.config.Strategy.Offers(currency, balance, book) {
+		if offer.Amount.LessThan(b.config.MinAmount) {
+			continue
+		}
+
+		if offer.Rate.LessThan(b.config.MinRate) {
+			offer.Rate = b.config.MinRate
+		}
+
+		if offer.Duration == 0 {
+			offer.Duration = durationFor(offer.Rate, b.config.Durations, DEFAULTDURATION)
+		}
+		offers = append(offers, offer)
+	}
+	return
+}
+
+// Cancel open offers older than StaleAfter.
+func (b *Bot) cancelStale() (cancelled []int64, err error) {
+	if b.config.StaleAfter <= 0 {
+		return
+	}
+
+	loanoffers, err := b.client.GetOpenLoanOffers()
+	if err != nil {
+		return
+	}
+
+	for _, offers := range loanoffers {
+		for _, offer := range offers {
+			if time.Since(offer.Date.Time) < b.config.StaleAfter {
+				continue
+			}
+
+			if !b.config.DryRun {
+				_, err = b.client.CancelLoanOffer(offer.ID)
+				if err != nil {
+					return cancelled, err
+				}
+			}
+			cancelled = append(cancelled, offer.ID)
+		}
+	}
+	return
+}
@@ -0,0 +1,106 @@
+package lending
+
+import (
+	polo "github.com/iowar/poloniex"
+	"github.com/shopspring/decimal"
+)
+
+// Loan offer planned by a strategy.
+// Zero duration is filled in from the duration rules of the bot.
+type Offer struct {
+	Currency string          `json:"currency"`
+	Amount   decimal.Decimal `json:"amount"`
+	Rate     decimal.Decimal `json:"rate"`
+	Duration int             `json:"duration"`
+}
+
+// Lending strategy.
+// Offers splits the idle balance of currency into loan offers
+// using the current loan order book.
+type Strategy interface {
+	Offers(currency string, balance decimal.Decimal, book polo.LoanOrder) []Offer
+}
+
+// Get the lowest offered rate of loan order book.
+func lowestRate(book polo.LoanOrder) (rate decimal.Decimal, ok bool) {
+	for i, v := range book.Offers {
+		if i == 0 || v.Rate.LessThan(rate) {
+			rate = v.Rate
+		}
+	}
+	return rate, len(book.Offers) > 0
+}
+
+// Offer the whole balance at the lowest offered rate.
+type MatchLowest struct{}
+
+func (MatchLowest) Offers(currency string, balance decimal.Decimal, book polo.LoanOrder) []Offer {
+	rate, ok := lowestRate(book)
+	if !ok {
+		return nil
+	}
+
+	return []Offer{{Currency: currency, Amount: balance, Rate: rate}}
+}
+
+// Split the balance into equal offers, starting at the
+// lowest offered rate and increasing the rate by Step.
+type Ladder struct {
+	Steps int             // number of offers
+	Step  decimal.Decimal // rate difference between offers
+}
+
+func (l Ladder) Offers(currency string, balance decimal.Decimal, book polo.LoanOrder) (offers []Offer) {
+	rate, ok := lowestRate(book)
+	if !ok {
+		return nil
+	}
+
+	steps := l.Steps
+	if steps < 1 {
+		steps = 1
+	}
+
+	amount := balance.Div(decimal.New(int64(steps), 0)).Truncate(8)
+	rest := balance.Sub(amount.Mul(decimal.New(int64(steps-1), 0)))
+
+	for i := 0; i < steps; i++ {
+		offer := Offer{
+			Currency: currency,
+			Amount:   amount,
+			Rate:     rate.Add(l.Step.Mul(decimal.New(int64(i), 0))),
+		}
+
+		// the last offer takes the remainder of the division.
+		if i == steps-1 {
+			offer.Amount = rest
+		}
+		offers = append(offers, offer)
+	}
+	return
+}
+
+// Duration of offers with rate at least Rate.
+type DurationRule struct {
+	Rate decimal.Decimal `json:"rate"`
+	Days int             `json:"days"`
+}
+
+// Pick duration for rate from rules.
+// The rule with the highest rate not above rate wins.
+func durationFor(rate decimal.Decimal, rules []DurationRule, def int) int {
+	var best *DurationRule
+	for i, v := range rules {
+		if v.Rate.GreaterThan(rate) {
+			continue
+		}
+		if best == nil || v.Rate.GreaterThan(best.Rate) {
+			best = &rules[i]
+		}
+	}
+
+	if best == nil {
+		return def
+	}
+	return best.Days
+}
@@ -0,0 +1,101 @@
+package lending
+
+import (
+	"time"
+
+	polo "github.com/iowar/poloniex"
+	"github.com/shopspring/decimal"
+)
+
+// Lending yield of currency.
+type Yield struct {
+	Currency    string          `json:"currency"`
+	Loans       int             `json:"loans"`       // closed loans in period
+	Lent        decimal.Decimal `json:"lent"`        // amount of closed loans
+	Earned      decimal.Decimal `json:"earned"`      // interest after fees
+	AverageRate decimal.Decimal `json:"averageRate"` // daily rate weighted by amount
+	Active      decimal.Decimal `json:"active"`      // amount of loans provided now
+	ActiveRate  decimal.Decimal `json:"activeRate"`  // daily rate of active loans weighted by amount
+}
+
+// Get lending yield by currency since start.
+func (b *Bot) Yield(start time.Time) (yields map[string]Yield, err error) {
+	yields = make(map[string]Yield)
+
+	history, err := b.lendingHistory(start, time.Now())
+	if err != nil {
+		return
+	}
+
+	weighted := make(map[string]decimal.Decimal)
+	for _, v := range history {
+		y := yields[v.Currency]
+		y.Currency = v.Currency
+		y.Loans++
+		y.Lent = y.Lent.Add(v.Amount)
+		y.Earned = y.Earned.Add(v.Earned)
+		weighted[v.Currency] = weighted[v.Currency].Add(v.Rate.Mul(v.Amount))
+		yields[v.Currency] = y
+	}
+
+	activeloans, err := b.client.GetActiveLoans()
+	if err != nil {
+		return
+	}
+
+	activeWeighted := make(map[string]decimal.Decimal)
+	for _, v := range activeloans.Provided {
+		y := yields[v.Currency]
+		y.Currency = v.Currency
+		y.Active = y.Active.Add(v.Amount)
+		activeWeighted[v.Currency] = activeWeighted[v.Currency].Add(v.Rate.Mul(v.Amount))
+		yields[v.Currency] = y
+	}
+
+	for k, y := range yields {
+		if y.Lent.IsPositive() {
+			y.AverageRate = weighted[k].DivRound(y.Lent, 8)
+		}
+		if y.Active.IsPositive() {
+			y.ActiveRate = activeWeighted[k].DivRound(y.Active, 8)
+		}
+		yields[k] = y
+	}
+	return
+}
+
+// Get all closed loans between start and end.
+// Full pages are followed by the window ending at their oldest loan,
+// loans are de-duplicated by id.
+func (b *Bot) lendingHistory(start, end time.Time) (history []polo.LendingHistory, err error) {
+	seen := make(map[int64]bool)
+
+	for {
+		page, err := b.client.GetLendingHistory(start, end, LENDINGPAGE)
+		if err != nil {
+			return nil, err
+		}
+
+		oldest := end
+		for _, v := range page {
+			if v.Close.Before(oldest) {
+				oldest = v.Close.Time
+			}
+			if seen[v.ID] {
+				continue
+			}
+			seen[v.ID] = true
+			history = append(history, v)
+		}
+
+		if len(page) < LENDINGPAGE {
+			return history, nil
+		}
+
+		// a full page closed within one second can not be walked further.
+		if !oldest.Before(end) {
+			return history, polo.Error(polo.LendingHistoryError, end.UTC().Format(polo.DateLayout))
+		}
+		end = oldest
+	}
+}
@@ -0,0 +1,207 @@
+package poloniex
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type MarginOrder struct {
+	Success int    `json:"success"`
+	Message string `json:"message"`
+	Move
+}
+
+func (mo *MarginOrder) UnmarshalJSON(b []byte) error {
+	var msg struct {
+		Success int    `json:"success"`
+		Message string `json:"message"`
+	}
+
+	err := json.Unmarshal(b, &msg)
+	if err != nil {
+		return err
+	}
+
+	// resulting trades are returned by market like moveOrder.
+	err = json.Unmarshal(b, &mo.Move)
+	if err != nil {
+		return err
+	}
+
+	mo.Success = msg.Success
+	mo.Message = msg.Message
+	return nil
+}
+
+// sub-function for margin order placement.
+func (p *Poloniex) placeMarginOrder(command, market string, price, amount, lendingRate decimal.Decimal, opts []OrderOptions) (marginorder MarginOrder, err error) {
+	side := SideBuy
+	if command == "marginSell" {
+		side = SideSell
+	}
+
+	rate, err := p.formatPrice(market, price, side)
+	if err != nil {
+		return
+	}
+
+	quantity, err := p.formatAmount(market, amount)
+	if err != nil {
+		return
+	}
+
+	parameters := map[string]string{
+		"currencyPair": strings.ToUpper(market),
+		"rate":         rate,
+		"amount":       quantity,
+	}
+
+	if lendingRate.IsPositive() {
+		parameters["lendingRate"] = lendingRate.String()
+	}
+
+	err = setOrderOptions(parameters, opts)
+	if err != nil {
+		return
+	}
+
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest(command, parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &marginorder)
+	return
+}
+
+// Place margin buy order.
+// lendingRate is the maximum lending rate to accept, zero for any rate.
+func (p *Poloniex) MarginBuy(market string, price, amount, lendingRate decimal.Decimal, opts ...OrderOptions) (MarginOrder, error) {
+	return p.placeMarginOrder("marginBuy", market, price, amount, lendingRate, opts)
+}
+
+// Place margin sell order.
+// lendingRate is the maximum lending rate to accept, zero for any rate.
+func (p *Poloniex) MarginSell(market string, price, amount, lendingRate decimal.Decimal, opts ...OrderOptions) (MarginOrder, error) {
+	return p.placeMarginOrder("marginSell", market, price, amount, lendingRate, opts)
+}
+
+type MarginPosition struct {
+	Amount           decimal.Decimal `json:"amount"`
+	Total            decimal.Decimal `json:"total"`
+	BasePrice        decimal.Decimal `json:"basePrice"`
+	LiquidationPrice decimal.Decimal `json:"liquidationPrice"`
+	PL               decimal.Decimal `json:"pl"`
+	LendingFees      decimal.Decimal `json:"lendingFees"`
+	Type             string          `json:"type"`
+}
+
+// Get margin position of market.
+func (p *Poloniex) GetMarginPosition(market string) (marginposition MarginPosition, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	parameters := map[string]string{"currencyPair": strings.ToUpper(market)}
+	go p.tradingRequest("getMarginPosition", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &marginposition)
+	return
+}
+
+// This method returns margin positions of all markets.
+func (p *Poloniex) GetAllMarginPositions() (marginpositions map[string]MarginPosition, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	parameters := map[string]string{"currencyPair": "all"}
+	go p.tradingRequest("getMarginPosition", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &marginpositions)
+	return
+}
+
+// Close margin position of market at market price.
+func (p *Poloniex) CloseMarginPosition(market string) (marginorder MarginOrder, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	parameters := map[string]string{"currencyPair": strings.ToUpper(market)}
+	go p.tradingRequest("closeMarginPosition", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &marginorder)
+	return
+}
+
+type MarginAccountSummary struct {
+	TotalValue         decimal.Decimal `json:"totalValue"`
+	PL                 decimal.Decimal `json:"pl"`
+	LendingFees        decimal.Decimal `json:"lendingFees"`
+	NetValue           decimal.Decimal `json:"netValue"`
+	TotalBorrowedValue decimal.Decimal `json:"totalBorrowedValue"`
+	CurrentMargin      decimal.Decimal `json:"currentMargin"`
+}
+
+func (p *Poloniex) GetMarginAccountSummary() (summary MarginAccountSummary, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("returnMarginAccountSummary", nil, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &summary)
+	return
+}
+
+// Get tradable balances of margin markets by market and currency.
+func (p *Poloniex) GetTradableBalances() (tradablebalances map[string]map[string]decimal.Decimal, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("returnTradableBalances", nil, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &tradablebalances)
+	return
+}
@@ -0,0 +1,186 @@
+package poloniex
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Metadata of market.
+// Trading status comes from tickers and currencies. The exchange does not
+// publish precision and minimum order totals, they are the client settings
+// of SetPrecision and SetMinimumTotal.
+type Market struct {
+	Pair         Pair
+	ID           int
+	Precision    Precision       // SetPrecision value, DefaultPrecision otherwise
+	PrecisionSet bool            // precision was set with SetPrecision
+	MinTotal     decimal.Decimal // minimum order total in base currency, see MinimumTotal
+	Frozen       bool            // market or one of its currencies is frozen
+	Delisted     bool            // one of its currencies is delisted
+	Disabled     bool            // deposits and withdrawals of one of its currencies are disabled
+}
+
+// It reports whether orders can be placed on market.
+func (m Market) Tradable() bool {
+	return !m.Frozen && !m.Delisted
+}
+
+// Default minimum order totals by base currency.
+// They are not published by the exchange and may be outdated,
+// override them per client with SetMinimumTotal.
+var MinimumTotals = map[string]decimal.Decimal{
+	"BTC":  decimal.New(1, -4),
+	"ETH":  decimal.New(1, -4),
+	"XMR":  decimal.New(1, -4),
+	"USDT": decimal.New(1, 0),
+	"USDC": decimal.New(1, 0),
+}
+
+// Minimum order total of base currencies missing in MinimumTotals.
+var DefaultMinimumTotal = decimal.New(1, -4)
+
+// Set minimum order total of markets with base currency.
+func (p *Poloniex) SetMinimumTotal(base string, total decimal.Decimal) {
+	p.precisionMutex.Lock()
+	defer p.precisionMutex.Unlock()
+
+	if p.minTotals == nil {
+		p.minTotals = make(map[string]decimal.Decimal)
+	}
+	p.minTotals[strings.ToUpper(base)] = total
+}
+
+// Get minimum order total of markets with base currency.
+// It is the SetMinimumTotal value, the MinimumTotals one
+// or DefaultMinimumTotal, in this order.
+func (p *Poloniex) MinimumTotal(base string) decimal.Decimal {
+	base = strings.ToUpper(base)
+
+	p.precisionMutex.RLock()
+	defer p.precisionMutex.RUnlock()
+
+	if total, ok := p.minTotals[base]; ok {
+		return total
+	}
+	if total, ok := MinimumTotals[base]; ok {
+		return total
+	}
+	return DefaultMinimumTotal
+}
+
+// Get metadata of all markets by market name.
+// Status is cached and reloaded after MARKETSTTL seconds,
+// precision and minimum total are the current client settings.
+func (p *Poloniex) Markets() (markets map[string]Market, err error) {
+	p.marketsMutex.RLock()
+	fresh := p.markets != nil && time.Since(p.marketsUpdated) < time.Second*MARKETSTTL
+	p.marketsMutex.RUnlock()
+
+	if !fresh {
+		err = p.RefreshMarkets()
+		if err != nil {
+			return
+		}
+	}
+
+	p.marketsMutex.RLock()
+	defer p.marketsMutex.RUnlock()
+
+	markets = make(map[string]Market, len(p.markets))
+	for name, market := range p.markets {
+		market.Precision, market.PrecisionSet = p.precision(name)
+		market.MinTotal = p.MinimumTotal(market.Pair.Base)
+		markets[name] = market
+	}
+	return
+}
+
+// Get metadata of market.
+func (p *Poloniex) Market(market string) (Market, error) {
+	markets, err := p.Markets()
+	if err != nil {
+		return Market{}, err
+	}
+
+	m, ok := markets[strings.ToUpper(market)]
+	if !ok {
+		return Market{}, Error(UnknownPairError, strings.ToUpper(market))
+	}
+	return m, nil
+}
+
+// Reload trading status of markets from tickers and currencies.
+func (p *Poloniex) RefreshMarkets() error {
+	tickers, err := p.GetTickers()
+	if err != nil {
+		return err
+	}
+
+	currencies, err := p.GetCurrencies()
+	if err != nil {
+		return err
+	}
+
+	markets := make(map[string]Market, len(tickers))
+	for name, ticker := range tickers {
+		pair, err := ParsePair(name)
+		if err != nil {
+			continue
+		}
+
+		market := Market{
+			Pair:   pair,
+			ID:     ticker.ID,
+			Frozen: ticker.IsFrozen != 0,
+		}
+
+		for _, code := range []string{pair.Base, pair.Quote} {
+			currency, ok := currencies[code]
+			if !ok {
+				continue
+			}
+			market.Frozen = market.Frozen || currency.Frozen != 0
+			market.Delisted = market.Delisted || currency.Delisted != 0
+			market.Disabled = market.Disabled || currency.Disabled != 0
+		}
+
+		markets[name] = market
+	}
+
+	p.marketsMutex.Lock()
+	p.markets = markets
+	p.marketsUpdated = time.Now()
+	p.marketsMutex.Unlock()
+	return nil
+}
+
+// Check order parameters of side against market metadata.
+// Price and amount are checked as they would be sent,
+// after rounding to the precision of market.
+func (p *Poloniex) ValidateOrder(market string, side Side, price, amount decimal.Decimal) error {
+	m, err := p.Market(market)
+	if err != nil {
+		return err
+	}
+
+	if !m.Tradable() {
+		return Error(MarketTradableError, m.Pair.String())
+	}
+
+	price, err = roundPrice(price, m.Precision.Price, side)
+	if err != nil {
+		return err
+	}
+
+	amount = amount.Truncate(m.Precision.Amount)
+	if !amount.IsPositive() {
+		return Error(OrderAmountError)
+	}
+
+	if total := price.Mul(amount); total.LessThan(m.MinTotal) {
+		return Error(OrderTotalError, total.String()+" < "+m.MinTotal.String())
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package poloniex
+
+import (
+	"strings"
+	"time"
+)
+
+// Currency pair of a market.
+// Quote currency is traded against base currency,
+// "BTC_ETH" is ETH priced in BTC.
+type Pair struct {
+	Base  string
+	Quote string
+}
+
+// Parse currency pair.
+// "BTC_ETH" and "btc-eth" are read base first,
+// "ETH/BTC" is read quote first.
+func ParsePair(s string) (pair Pair, err error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	if i := strings.Index(s, "/"); i >= 0 {
+		pair = Pair{Base: s[i+1:], Quote: s[:i]}
+	} else if i := strings.IndexAny(s, "_-"); i >= 0 {
+		pair = Pair{Base: s[:i], Quote: s[i+1:]}
+	} else {
+		return Pair{}, Error(PairError, s)
+	}
+
+	if !validCurrency(pair.Base) || !validCurrency(pair.Quote) {
+		return Pair{}, Error(PairError, s)
+	}
+	return
+}
+
+// It reports whether currency is a non-empty alphanumeric code.
+func validCurrency(currency string) bool {
+	if currency == "" {
+		return false
+	}
+
+	for _, r := range currency {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Market name of pair, "BTC_ETH", empty for the zero pair.
+func (pair Pair) String() string {
+	if pair.IsZero() {
+		return ""
+	}
+	return pair.Base + "_" + pair.Quote
+}
+
+func (pair Pair) IsZero() bool {
+	return pair.Base == "" && pair.Quote == ""
+}
+
+// The zero pair is encoded as an empty string.
+func (pair Pair) MarshalText() ([]byte, error) {
+	return []byte(pair.String()), nil
+}
+
+// An empty string is decoded as the zero pair.
+func (pair *Pair) UnmarshalText(b []byte) (err error) {
+	if len(b) == 0 {
+		*pair = Pair{}
+		return nil
+	}
+
+	*pair, err = ParsePair(string(b))
+	return
+}
+
+// Reload markets used by ValidatePair.
+func (p *Poloniex) RefreshPairs() error {
+	return p.RefreshMarkets()
+}
+
+// Check pair against the markets of the exchange.
+// Markets are cached, see Markets.
+func (p *Poloniex) ValidatePair(pair Pair) error {
+	_, err := p.Market(pair.String())
+	return err
+}
+
+// Parse and validate currency pair.
+func (p *Poloniex) LookupPair(s string) (pair Pair, err error) {
+	pair, err = ParsePair(s)
+	if err != nil {
+		return
+	}
+
+	err = p.ValidatePair(pair)
+	return
+}
+
+func (p *Poloniex) GetOrderBookPair(pair Pair, depth int) (orderbook OrderBook, err error) {
+	if err = p.ValidatePair(pair); err != nil {
+		return
+	}
+	return p.GetOrderBook(pair.String(), depth)
+}
+
+func (p *Poloniex) GetPublicTradeHistoryPair(pair Pair, args ...time.Time) (trades []PublicTrade, err error) {
+	if err = p.ValidatePair(pair); err != nil {
+		return
+	}
+	return p.GetPublicTradeHistory(pair.String(), args...)
+}
+
+func (p *Poloniex) GetChartDataPair(pair Pair, start, end time.Time, period string) (candles []CandleStick, err error) {
+	if err = p.ValidatePair(pair); err != nil {
+		return
+	}
+	return p.GetChartData(pair.String(), start, end, period)
+}
+
+func (p *Poloniex) GetOpenOrdersPair(pair Pair) (openorders []OpenOrder, err error) {
+	if err = p.ValidatePair(pair); err != nil {
+		return
+	}
+	return p.GetOpenOrders(pair.String())
+}
+
+func (p *Poloniex) GetTradeHistoryPair(pair Pair, start, end time.Time, limit int) (tradehistory []TradeHistory, err error) {
+	if err = p.ValidatePair(pair); err != nil {
+		return
+	}
+	return p.GetTradeHistory(pair.String(), start, end, limit)
+}
+
+func (p *Poloniex) CancelAllOrdersPair(pair Pair) (orderNumbers []string, err error) {
+	if err = p.ValidatePair(pair); err != nil {
+		return
+	}
+	return p.CancelAllOrders(pair.String())
+}
+
+func (p *Poloniex) BuyPair(pair Pair, price, amount float64, opts ...OrderOptions) (buy Buy, err error) {
+	if err = p.ValidatePair(pair); err != nil {
+		return
+	}
+	return p.Buy(pair.String(), price, amount, opts...)
+}
+
+func (p *Poloniex) SellPair(pair Pair, price, amount float64, opts ...OrderOptions) (sell Sell, err error) {
+	if err = p.ValidatePair(pair); err != nil {
+		return
+	}
+	return p.Sell(pair.String(), price, amount, opts...)
+}
+
+// Subscribe to market of pair.
+// Unknown markets are rejected by the channel list of the server.
+func (ws *WSClient) SubscribePair(pair Pair) (*Subscription, error) {
+	return ws.Subscribe(pair.String())
+}
+
+func (ws *WSClient) SubscribePairs(pairs ...Pair) (<-chan PairUpdate, error) {
+	return ws.SubscribeMarkets(pairNames(pairs)...)
+}
+
+func (ws *WSClient) UnsubscribePairs(pairs ...Pair) error {
+	return ws.UnsubscribeMarkets(pairNames(pairs)...)
+}
+
+func (p *WSPool) SubscribePair(pair Pair) (*PoolSubscription, error) {
+	return p.Subscribe(pair.String())
+}
+
+func pairNames(pairs []Pair) []string {
+	names := make([]string, len(pairs))
+	for i, pair := range pairs {
+		names[i] = pair.String()
+	}
+	return names
+}
@@ -0,0 +1,60 @@
+package poloniex
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParsePair(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Pair
+		ok   bool
+	}{
+		{"BTC_ETH", Pair{Base: "BTC", Quote: "ETH"}, true},
+		{"btc_eth", Pair{Base: "BTC", Quote: "ETH"}, true},
+		{"btc-eth", Pair{Base: "BTC", Quote: "ETH"}, true},
+		{"ETH/BTC", Pair{Base: "BTC", Quote: "ETH"}, true},
+		{" usdt_btc ", Pair{Base: "USDT", Quote: "BTC"}, true},
+		{"USDT_1INCH", Pair{Base: "USDT", Quote: "1INCH"}, true},
+		{"BTCETH", Pair{}, false},
+		{"BTC_", Pair{}, false},
+		{"_ETH", Pair{}, false},
+		{"BTC_E.TH", Pair{}, false},
+		{"", Pair{}, false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePair(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParsePair(%q) = %v, %v, want %v, ok %v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPairText(t *testing.T) {
+	type msg struct {
+		P Pair
+	}
+
+	tests := []struct {
+		pair Pair
+		json string
+	}{
+		{Pair{Base: "BTC", Quote: "ETH"}, `{"P":"BTC_ETH"}`},
+		{Pair{}, `{"P":""}`},
+	}
+
+	for _, tt := range tests {
+		b, err := json.Marshal(msg{tt.pair})
+		if err != nil || string(b) != tt.json {
+			t.Errorf("Marshal(%v) = %s, %v, want %s", tt.pair, b, err, tt.json)
+			continue
+		}
+
+		var got msg
+		if err := json.Unmarshal(b, &got); err != nil || got.P != tt.pair {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", b, got.P, err, tt.pair)
+		}
+	}
+}
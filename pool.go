@@ -0,0 +1,548 @@
+package poloniex
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	POOLCHECK = 5 // Pool Health Check Interval (seconds)
+)
+
+// Pool of web socket clients.
+// Subscriptions are sharded across the connections by channel,
+// so a busy or slow channel only affects its own connection.
+type WSPool struct {
+	clients    []*WSClient                    // pooled connections
+	assign     map[string]int                 // client index by channel name
+	subs       map[string][]*PoolSubscription // subscription handles by channel name
+	merged     chan PairUpdate                // merged market stream
+	mergedSubs map[string]*PoolSubscription   // handles feeding merged stream
+	status     []bool                         // last seen connection status
+	done       chan struct{}                  // stops monitor, closed by Close
+	closed     bool
+	mergedWG   sync.WaitGroup // goroutines feeding merged stream
+	sync.Mutex                // embedded mutex
+}
+
+// Subscription handle of the pool.
+// The handle survives moving its channel between connections.
+type PoolSubscription struct {
+	C       <-chan interface{} // updates, closed by Unsubscribe
+	Channel string             // channel name
+	ch      chan interface{}
+	sub     *Subscription // current handle on the pooled connection
+	wg      sync.WaitGroup
+	pool    *WSPool
+	closed  bool
+}
+
+// Aggregate health status of the pool.
+type PoolStatus struct {
+	Connections int        `json:"connections"`
+	Connected   int        `json:"connected"`
+	Channels    int        `json:"channels"`
+	Clients     []WSStatus `json:"clients"`
+}
+
+// It reports whether all connections of the pool are up.
+func (ps PoolStatus) Healthy() bool {
+	return ps.Connected == ps.Connections
+}
+
+// Create new pool with size web socket clients.
+func NewWSPool(size int) (pool *WSPool, err error) {
+	if size < 1 {
+		return nil, Error(PoolSizeError)
+	}
+
+	pool = &WSPool{
+		assign:     make(map[string]int),
+		subs:       make(map[string][]*PoolSubscription),
+		merged:     make(chan PairUpdate, MERGEDBUFFER),
+		mergedSubs: make(map[string]*PoolSubscription),
+		done:       make(chan struct{}),
+	}
+
+	for i := 0; i < size; i++ {
+		client, err := NewWSClient()
+		if err != nil {
+			for _, c := range pool.clients {
+				c.Close()
+			}
+			return nil, err
+		}
+		pool.clients = append(pool.clients, client)
+		pool.status = append(pool.status, true)
+	}
+
+	go pool.monitor()
+	return
+}
+
+// Check connections periodically and rebalance
+// subscriptions when a connection goes down or comes back.
+func (p *WSPool) monitor() {
+	ticker := time.NewTicker(time.Second * POOLCHECK)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-p.done:
+			return
+		}
+
+		changed := false
+
+		p.Lock()
+		for i, client := range p.clients {
+			connected := client.Status().Connected
+			if connected != p.status[i] {
+				p.status[i] = connected
+				changed = true
+			}
+		}
+		p.Unlock()
+
+		if changed {
+			p.Rebalance()
+		}
+	}
+}
+
+// Get aggregate health status.
+func (p *WSPool) Status() (status PoolStatus) {
+	p.Lock()
+	defer p.Unlock()
+
+	status.Connections = len(p.clients)
+	status.Channels = len(p.assign)
+	for _, client := range p.clients {
+		st := client.Status()
+		if st.Connected {
+			status.Connected++
+		}
+		status.Clients = append(status.Clients, st)
+	}
+	return
+}
+
+// Set the number of levels kept on each side of
+// order depth snapshots for all connections.
+func (p *WSPool) SetDepthLimit(depth int) {
+	for _, client := range p.clients {
+		client.SetDepthLimit(depth)
+	}
+}
+
+// Count channels by client index.
+// It must be called with the lock held.
+func (p *WSPool) loads() []int {
+	loads := make([]int, len(p.clients))
+	for _, i := range p.assign {
+		loads[i]++
+	}
+	return loads
+}
+
+// Pick the connected client with the fewest channels.
+// It must be called with the lock held.
+func (p *WSPool) pick() (int, error) {
+	loads := p.loads()
+	best := -1
+	for i := range p.clients {
+		if !p.status[i] {
+			continue
+		}
+		if best < 0 || loads[i] < loads[best] {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		return best, Error(PoolConnectError)
+	}
+	return best, nil
+}
+
+// Subscribe to ticker or market channel and get a new handle.
+// Handles of the same channel share one connection.
+func (p *WSPool) Subscribe(chname string) (*PoolSubscription, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.subscribe(strings.ToUpper(chname))
+}
+
+// sub-function for subscription.
+// It must be called with the lock held.
+func (p *WSPool) subscribe(chname string) (ps *PoolSubscription, err error) {
+	if p.closed {
+		err = Error(PoolClosedError)
+		return
+	}
+
+	i, ok := p.assign[chname]
+	if !ok {
+		i, err = p.pick()
+		if err != nil {
+			return
+		}
+	}
+
+	sub, err := p.clients[i].Subscribe(chname)
+	if err != nil {
+		return
+	}
+
+	ch := make(chan interface{}, SUBSBUFFER)
+	ps = &PoolSubscription{
+		C:       ch,
+		Channel: chname,
+		ch:      ch,
+		pool:    p,
+	}
+	ps.attach(sub)
+
+	p.assign[chname] = i
+	p.subs[chname] = append(p.subs[chname], ps)
+	return
+}
+
+// Forward updates of the handle on the pooled connection.
+func (ps *PoolSubscription) attach(sub *Subscription) {
+	ps.sub = sub
+	ps.wg.Add(1)
+	go func() {
+		defer ps.wg.Done()
+		for update := range sub.C {
+			select {
+			case ps.ch <- update:
+			default:
+			}
+		}
+	}()
+}
+
+// Unsubscribe and close the subscription channel.
+// It returns nil if successful or already unsubscribed.
+func (ps *PoolSubscription) Unsubscribe() error {
+	p := ps.pool
+
+	p.Lock()
+	ok := p.remove(ps)
+	p.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return ps.release()
+}
+
+// Remove handle from the pool.
+// It reports whether the handle was still subscribed.
+// It must be called with the lock held.
+func (p *WSPool) remove(ps *PoolSubscription) bool {
+	if ps.closed {
+		return false
+	}
+	ps.closed = true
+
+	subs := p.subs[ps.Channel]
+	for i, s := range subs {
+		if s == ps {
+			subs = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+
+	if len(subs) == 0 {
+		delete(p.subs, ps.Channel)
+		delete(p.assign, ps.Channel)
+	} else {
+		p.subs[ps.Channel] = subs
+	}
+	return true
+}
+
+// Unsubscribe removed handle from its connection and close its channel.
+// It waits for the acknowledgement, so it must be called without the lock.
+func (ps *PoolSubscription) release() error {
+	ps.pool.Lock()
+	sub := ps.sub
+	ps.pool.Unlock()
+
+	err := sub.Unsubscribe()
+	ps.wg.Wait()
+	close(ps.ch)
+	return err
+}
+
+// Move all handles of channel to client with index to.
+// Handles are moved only if all of them could be subscribed on the
+// new connection, otherwise the channel stays where it is.
+// It returns the handles left on the old connection, or the unused
+// ones of the new connection on failure, to be released with
+// releaseHandles. It must be called with the lock held.
+func (p *WSPool) move(chname string, to int) (old []*Subscription, err error) {
+	handles := p.subs[chname]
+
+	subs := make([]*Subscription, 0, len(handles))
+	for range handles {
+		var sub *Subscription
+		sub, err = p.clients[to].Subscribe(chname)
+		if err != nil {
+			return subs, err
+		}
+		subs = append(subs, sub)
+	}
+
+	for i, ps := range handles {
+		old = append(old, ps.sub)
+		ps.attach(subs[i])
+	}
+
+	p.assign[chname] = to
+	return old, nil
+}
+
+// Release handles of pooled connections.
+// Handles of disconnected clients are dropped without unsubscribing.
+// It waits for the acknowledgements, so it must be called without the lock.
+func releaseHandles(subs []*Subscription) error {
+	var failed []string
+	for _, sub := range subs {
+		if !sub.ws.Status().Connected {
+			sub.drop()
+			continue
+		}
+		if err := sub.Unsubscribe(); err != nil {
+			failed = append(failed, sub.Channel)
+		}
+	}
+
+	if len(failed) > 0 {
+		return Error(UnsubscribeError, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Move channels off the disconnected clients and
+// spread them evenly over the connected ones.
+// Old connections are unsubscribed after the pool is unlocked.
+func (p *WSPool) Rebalance() error {
+	p.Lock()
+	old, err := p.rebalance()
+	p.Unlock()
+
+	if rerr := releaseHandles(old); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// sub-function for Rebalance.
+// It must be called with the lock held.
+func (p *WSPool) rebalance() (old []*Subscription, err error) {
+	for chname, i := range p.assign {
+		if p.status[i] {
+			continue
+		}
+
+		var to int
+		to, err = p.pick()
+		if err != nil {
+			return
+		}
+
+		var moved []*Subscription
+		moved, err = p.move(chname, to)
+		old = append(old, moved...)
+		if err != nil {
+			return
+		}
+	}
+
+	for {
+		loads := p.loads()
+		min, max := -1, -1
+		for i := range p.clients {
+			if !p.status[i] {
+				continue
+			}
+			if min < 0 || loads[i] < loads[min] {
+				min = i
+			}
+			if max < 0 || loads[i] > loads[max] {
+				max = i
+			}
+		}
+
+		if min < 0 || loads[max]-loads[min] < 2 {
+			return
+		}
+
+		for chname, i := range p.assign {
+			if i != max {
+				continue
+			}
+
+			var moved []*Subscription
+			moved, err = p.move(chname, min)
+			old = append(old, moved...)
+			if err != nil {
+				return
+			}
+			break
+		}
+	}
+}
+
+// Stop monitoring, close the connections and all handles.
+// The merged stream is closed as well.
+func (p *WSPool) Close() error {
+	p.Lock()
+	if p.closed {
+		p.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+
+	var removed []*PoolSubscription
+	for _, subs := range p.subs {
+		removed = append(removed, subs...)
+	}
+	for _, ps := range removed {
+		p.remove(ps)
+	}
+	p.mergedSubs = make(map[string]*PoolSubscription)
+	p.Unlock()
+
+	// closed connections close their handles, releasing them only
+	// waits for the forwarding goroutines.
+	var err error
+	for _, client := range p.clients {
+		if cerr := client.Close(); err == nil {
+			err = cerr
+		}
+	}
+	for _, ps := range removed {
+		ps.release()
+	}
+
+	p.mergedWG.Wait()
+	close(p.merged)
+	return err
+}
+
+// Subscribe to market channels with one merged stream.
+// Each update is tagged with its market name.
+// Unknown markets are skipped and reported in the returned error,
+// the stream is still returned for the rest of them.
+func (p *WSPool) SubscribeMarkets(chnames ...string) (<-chan PairUpdate, error) {
+	var unknown []string
+
+	p.Lock()
+	defer p.Unlock()
+
+	for _, chname := range chnames {
+		chname = strings.ToUpper(chname)
+		chid, ok := channelsByName[chname]
+		if !ok || chid == TICKER {
+			unknown = append(unknown, chname)
+			continue
+		}
+
+		if p.mergedSubs[chname] != nil {
+			continue
+		}
+
+		ps, err := p.subscribe(chname)
+		if err != nil {
+			return p.merged, err
+		}
+		p.mergedSubs[chname] = ps
+
+		p.mergedWG.Add(1)
+		go func() {
+			defer p.mergedWG.Done()
+			for update := range ps.C {
+				if updates, ok := update.([]MarketUpdate); ok {
+					select {
+					case p.merged <- PairUpdate{Pair: ps.Channel, Updates: updates}:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+	if len(unknown) > 0 {
+		return p.merged, Error(ChannelsError, strings.Join(unknown, ", "))
+	}
+	return p.merged, nil
+}
+
+// Subscribe to all market channels with one merged stream.
+func (p *WSPool) SubscribeAllMarkets() (<-chan PairUpdate, error) {
+	var chnames []string
+	for _, chid := range marketChannels {
+		chnames = append(chnames, channelsByID[chid])
+	}
+	return p.SubscribeMarkets(chnames...)
+}
+
+// Unsubscribe market channels from merged stream.
+// Unknown markets are skipped and reported in the returned error.
+func (p *WSPool) UnsubscribeMarkets(chnames ...string) error {
+	var unknown []string
+	var removed []*PoolSubscription
+
+	p.Lock()
+	for _, chname := range chnames {
+		chname = strings.ToUpper(chname)
+		ps, ok := p.mergedSubs[chname]
+		if !ok {
+			if _, ok := channelsByName[chname]; !ok {
+				unknown = append(unknown, chname)
+			}
+			continue
+		}
+
+		delete(p.mergedSubs, chname)
+		if p.remove(ps) {
+			removed = append(removed, ps)
+		}
+	}
+	p.Unlock()
+
+	var err error
+	for _, ps := range removed {
+		if rerr := ps.release(); err == nil {
+			err = rerr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(unknown) > 0 {
+		return Error(ChannelsError, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Unsubscribe all market channels from merged stream.
+func (p *WSPool) UnsubscribeAllMarkets() error {
+	p.Lock()
+	var chnames []string
+	for chname := range p.mergedSubs {
+		chnames = append(chnames, chname)
+	}
+	p.Unlock()
+
+	return p.UnsubscribeMarkets(chnames...)
+}
@@ -18,7 +18,7 @@ type Ticker struct {
 	PercentChange decimal.Decimal `json:"percentChange, string"`
 	BaseVolume    decimal.Decimal `json:"baseVolume, string"`
 	QuoteVolume   decimal.Decimal `json:"quoteVolume, string"`
-	IsFrozen      int             `json:"isFrozen ,string"`
+	IsFrozen      int             `json:"isFrozen,string"`
 	High24hr      decimal.Decimal `json:"high24hr, string"`
 	Low24hr       decimal.Decimal `json:"low24hr, string"`
 }
@@ -191,8 +191,8 @@ func (p *Poloniex) GetOrderBook(market string, depth int) (orderbook OrderBook,
 type PublicTrade struct {
 	GlobalTradeID uint64          `json:"globalTradeID"`
 	TradeID       uint64          `json:"tradeID"`
-	Date          string          `json:"date, string"`
-	Type          string          `json:"type, string"`
+	Date          PoloTime        `json:"date"`
+	Type          Side            `json:"type"`
 	Rate          decimal.Decimal `json:"rate, string"`
 	Amount        decimal.Decimal `json:"amount, string"`
 	Total         decimal.Decimal `json:"total, string"`
@@ -222,60 +222,126 @@ func (p *Poloniex) GetPublicTradeHistory(market string, args ...time.Time) (trad
 }
 
 type CandleStick struct {
-	Date            int64   `json:"date"`
-	High            float64 `json:"high"`
-	Low             float64 `json:"low"`
-	Open            float64 `json:"open"`
-	Close           float64 `json:"close"`
-	Volume          float64 `json:"volume"`
-	QuoteVolume     float64 `json:"quoteVolume"`
-	WeightedAverage float64 `json:"weightedAverage"`
+	Date            PoloTime `json:"date"`
+	High            float64  `json:"high"`
+	Low             float64  `json:"low"`
+	Open            float64  `json:"open"`
+	Close           float64  `json:"close"`
+	Volume          float64  `json:"volume"`
+	QuoteVolume     float64  `json:"quoteVolume"`
+	WeightedAverage float64  `json:"weightedAverage"`
 }
 
-func (p *Poloniex) GetChartData(market string, start, end time.Time, period string) (candles []CandleStick, err error) {
-	var periodSec int
-	var v1, v2 int64
-
-	switch period {
-	case "5m":
-		periodSec = 300
-	case "15m":
-		periodSec = 900
-	case "30m":
-		periodSec = 1800
-	case "2h":
-		periodSec = 7200
-	case "4h":
-		periodSec = 14400
-	case "1d":
-		periodSec = 86400
-	default:
-		return nil, Error(PeriodError)
-	}
+// Candle period of chart data.
+type Period int64
 
-	action := fmt.Sprintf("returnChartData&currencyPair=%s",
-		strings.ToUpper(market))
+const (
+	Period5m  Period = 300
+	Period15m Period = 900
+	Period30m Period = 1800
+	Period2h  Period = 7200
+	Period4h  Period = 14400
+	Period1d  Period = 86400
+)
 
-	if start.IsZero() == false && end.IsZero() == false {
-		v1 = start.Unix()
-		v2 = end.Unix()
+// Periods served by the exchange.
+var Periods = []Period{Period5m, Period15m, Period30m, Period2h, Period4h, Period1d}
+
+var periodNames = map[Period]string{
+	Period5m:  "5m",
+	Period15m: "15m",
+	Period30m: "30m",
+	Period2h:  "2h",
+	Period4h:  "4h",
+	Period1d:  "1d",
+}
 
-		if int((v2 - v1)) < periodSec {
-			return nil, Error(TimePeriodError)
+// Parse period from its name, "5m", or from a duration, "300s".
+func ParsePeriod(s string) (Period, error) {
+	for period, name := range periodNames {
+		if s == name {
+			return period, nil
 		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, Error(PeriodError)
+	}
+	return PeriodFromDuration(d)
+}
 
-	} else if start.IsZero() == true && end.IsZero() == true {
-		v1 = time.Now().AddDate(0, 0, -1).Unix()
-		v2 = time.Now().Unix()
-	} else {
+// Get period of duration.
+func PeriodFromDuration(d time.Duration) (Period, error) {
+	period := Period(d / time.Second)
+	if !period.valid() {
+		return 0, Error(PeriodError)
+	}
+	return period, nil
+}
+
+// It reports whether period is served by the exchange.
+func (period Period) valid() bool {
+	_, ok := periodNames[period]
+	return ok
+}
+
+func (period Period) Duration() time.Duration {
+	return time.Duration(period) * time.Second
+}
+
+func (period Period) String() string {
+	if name, ok := periodNames[period]; ok {
+		return name
+	}
+	return period.Duration().String()
+}
+
+func (period Period) MarshalText() ([]byte, error) {
+	return []byte(period.String()), nil
+}
+
+func (period *Period) UnmarshalText(b []byte) (err error) {
+	*period, err = ParsePeriod(string(b))
+	return
+}
+
+// Get candles of market between start and end.
+// Period is a name or a duration accepted by ParsePeriod,
+// both times are required, see GetRecentChartData.
+func (p *Poloniex) GetChartData(market string, start, end time.Time, period string) (candles []CandleStick, err error) {
+	per, err := ParsePeriod(period)
+	if err != nil {
+		return
+	}
+
+	if start.IsZero() || end.IsZero() {
 		return nil, Error(TimeError)
 	}
 
+	v1 := start.Unix()
+	v2 := end.Unix()
+
+	if v2-v1 < int64(per) {
+		return nil, Error(TimePeriodError)
+	}
+
+	return p.getChartData(market, v1, v2, per)
+}
+
+// Get candles of market of the last 24 hours.
+func (p *Poloniex) GetRecentChartData(market string, period string) (candles []CandleStick, err error) {
+	end := time.Now()
+	return p.GetChartData(market, end.AddDate(0, 0, -1), end, period)
+}
+
+// sub-function for chart data of window [start, end] in seconds.
+func (p *Poloniex) getChartData(market string, start, end int64, period Period) (candles []CandleStick, err error) {
 	respch := make(chan []byte)
 	errch := make(chan error)
 
-	action += fmt.Sprintf("&start=%d&end=%d&period=%d",
-		v1, v2, periodSec)
+	action := fmt.Sprintf("returnChartData&currencyPair=%s&start=%d&end=%d&period=%d",
+		strings.ToUpper(market), start, end, period)
 
 	go p.publicRequest(action, respch, errch)
 
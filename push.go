@@ -1,7 +1,9 @@
 package poloniex
 
 import (
+	"context"
 	"encoding/json"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -11,14 +13,19 @@ import (
 )
 
 const (
-	TICKER     = 1002 // Ticker Channel Id
-	SUBSBUFFER = 24   // Subscriptions Buffer
+	TICKER       = 1002 // Ticker Channel Id
+	SUBSBUFFER   = 24   // Subscriptions Buffer
+	MERGEDBUFFER = 256  // Merged Subscriptions Buffer
+	ERRORBUFFER  = 64   // Update Parse Errors Buffer
+	ACKTIMEOUT   = 10   // Unsubscription Acknowledgement Timeout (seconds)
+	MAXREDIAL    = 60   // Maximum Wait Between Reconnection Attempts (seconds)
 )
 
 var (
 	channelsByName = make(map[string]int) // channels map by name
 	channelsByID   = make(map[int]string) // channels map by id
 	marketChannels []int                  // channels list
+	channelsMutex  sync.Mutex             // prevent loading channels twice
 )
 
 // subscription and unsubscription
@@ -52,7 +59,13 @@ type WSTicker struct {
 // for market update.
 type MarketUpdate struct {
 	Data       interface{}
-	TypeUpdate string `json:"type"`
+	TypeUpdate UpdateKind `json:"type"`
+}
+
+// for merged market updates.
+type PairUpdate struct {
+	Pair    string         `json:"pair"`
+	Updates []MarketUpdate `json:"updates"`
 }
 
 // "i" messages.
@@ -67,7 +80,7 @@ type OrderDepth struct {
 // "o" messages
 type WSOrderBook struct {
 	Rate      float64 `json:"rate,string"`
-	TypeOrder string  `json:"type"`
+	TypeOrder Side    `json:"type"`
 	Amount    float64 `json:"amount,string"`
 }
 
@@ -77,7 +90,7 @@ type WSOrderBookModify WSOrderBook
 // "o" messages.
 type WSOrderBookRemove struct {
 	Rate      float64 `json:"rate,string"`
-	TypeOrder string  `json:"type"`
+	TypeOrder Side    `json:"type"`
 }
 
 // "t" messages.
@@ -86,16 +99,67 @@ type NewTrade struct {
 	Rate      float64 `json:"rate,string"`
 	Amount    float64 `json:"amount,string"`
 	Total     float64 `json:"total,string"`
-	TypeOrder string  `json:"type"`
+	TypeOrder Side    `json:"type"`
 }
 
 type WSClient struct {
 	Subs       map[string]chan interface{} // subscriptions map
+	subs       map[string]*Subscription    // handles behind subscriptions map
+	handles    map[string][]*Subscription  // subscription handles by channel name
+	acks       map[int]chan struct{}       // pending unsubscription acknowledgements
 	wsConn     *websocket.Conn             // websocket connection
+	dialer     *websocket.Dialer           // websocket dialer for reconnection
+	connected  bool                        // connection status
+	closed     bool                        // closed by Close
+	ctx        context.Context             // cancelled by Close, stops redialing
+	cancel     context.CancelFunc          // cancel of ctx
+	stopped    chan struct{}               // closed when the handler goroutine ends
+	reconnects int                         // number of reconnections
+	lastMsg    time.Time                   // time of the last message
+	depth      int                         // order depth snapshot limit
+	merged     chan PairUpdate             // merged market stream
+	errs       chan error                  // update parse errors
+	mergedSubs map[string]bool             // markets sent to merged stream
 	wsMutex    *sync.Mutex                 // prevent race condition for websocket RW
+	subsMutex  *sync.Mutex                 // serialize subscribe and unsubscribe commands
 	sync.Mutex                             // embedded mutex
 }
 
+// Connection status of web socket client.
+type WSStatus struct {
+	Connected   bool      `json:"connected"`
+	Reconnects  int       `json:"reconnects"`
+	LastMessage time.Time `json:"lastMessage"`
+	Channels    int       `json:"channels"`
+}
+
+// Subscription handle.
+// Every handle has its own channel, so the same channel can be
+// subscribed by several independent consumers.
+type Subscription struct {
+	C       <-chan interface{} // updates, closed by Unsubscribe
+	Channel string             // channel name
+	ch      chan interface{}
+	ws      *WSClient
+	closed  bool
+	keep    bool // channel belongs to Subs map and is never closed
+}
+
+// Set the number of levels kept on each side of order depth snapshots.
+// Zero or a negative value keeps the whole book.
+func (ws *WSClient) SetDepthLimit(depth int) {
+	ws.Lock()
+	defer ws.Unlock()
+	ws.depth = depth
+}
+
+// Get order depth snapshot limit.
+func (ws *WSClient) DepthLimit() int {
+	ws.Lock()
+	defer ws.Unlock()
+	return ws.depth
+}
+
 // Web socket reader.
 func (ws *WSClient) readMessage() ([]byte, error) {
 	ws.wsMutex.Lock()
@@ -126,6 +190,14 @@ func setChannelsId() (err error) {
 		return err
 	}
 
+	// channels are loaded once and shared by all clients.
+	channelsMutex.Lock()
+	defer channelsMutex.Unlock()
+
+	if len(marketChannels) > 0 {
+		return
+	}
+
 	for k, v := range tickers {
 		channelsByName[k] = v.ID
 		channelsByID[v.ID] = k
@@ -148,28 +220,169 @@ func NewWSClient() (wsClient *WSClient, err error) {
 		return
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	wsClient = &WSClient{
-		wsConn:  ws,
-		Subs:    make(map[string]chan interface{}),
-		wsMutex: &sync.Mutex{},
+		wsConn:     ws,
+		ctx:        ctx,
+		cancel:     cancel,
+		stopped:    make(chan struct{}),
+		dialer:     dialer,
+		connected:  true,
+		lastMsg:    time.Now(),
+		Subs:       make(map[string]chan interface{}),
+		subs:       make(map[string]*Subscription),
+		handles:    make(map[string][]*Subscription),
+		acks:       make(map[int]chan struct{}),
+		merged:     make(chan PairUpdate, MERGEDBUFFER),
+		errs:       make(chan error, ERRORBUFFER),
+		mergedSubs: make(map[string]bool),
+		wsMutex:    &sync.Mutex{},
+		subsMutex:  &sync.Mutex{},
 	}
 
 	if err = setChannelsId(); err != nil {
-		return
+		cancel()
+		ws.Close()
+		return nil, err
 	}
 
 	go func() {
+		defer close(wsClient.stopped)
 		for {
 			err := wsClient.wsHandler()
-			if err != nil {
-				ws, _, _ := dialer.Dial(pushAPIUrl, nil)
-				wsClient.wsConn = ws
+			if err != nil && !wsClient.reconnect() {
+				return
 			}
 		}
 	}()
 	return
 }
 
+// Close connection and stop reconnecting.
+// Subscription handles, the chans of Subs map, the merged stream
+// and the error chan are closed.
+func (ws *WSClient) Close() error {
+	ws.Lock()
+	if ws.closed {
+		ws.Unlock()
+		return nil
+	}
+	ws.closed = true
+	ws.connected = false
+	ws.cancel()
+	conn := ws.wsConn
+	ws.Unlock()
+
+	// the handler ends on the read error of the closed connection.
+	err := conn.Close()
+	<-ws.stopped
+
+	ws.subsMutex.Lock()
+	defer ws.subsMutex.Unlock()
+
+	ws.Lock()
+	defer ws.Unlock()
+
+	for _, handles := range ws.handles {
+		for _, sub := range handles {
+			sub.closed = true
+			if !sub.keep {
+				close(sub.ch)
+			}
+		}
+	}
+	for _, ch := range ws.Subs {
+		close(ch)
+	}
+
+	ws.handles = make(map[string][]*Subscription)
+	ws.subs = make(map[string]*Subscription)
+	ws.mergedSubs = make(map[string]bool)
+	close(ws.merged)
+	close(ws.errs)
+	return err
+}
+
+// Reconnect web socket and subscribe to the channels in use again.
+// It retries until the connection is established or the client is
+// closed, it reports whether the connection was established.
+func (ws *WSClient) reconnect() bool {
+	ws.Lock()
+	ws.connected = false
+	ws.Unlock()
+
+	wait := time.Second
+	for {
+		conn, _, err := ws.dialer.DialContext(ws.ctx, pushAPIUrl, nil)
+		if err == nil {
+			ws.Lock()
+			if ws.closed {
+				ws.Unlock()
+				conn.Close()
+				return false
+			}
+			ws.wsMutex.Lock()
+			ws.wsConn.Close()
+			ws.wsConn = conn
+			ws.wsMutex.Unlock()
+			ws.Unlock()
+			break
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ws.ctx.Done():
+			return false
+		}
+		if wait *= 2; wait > time.Second*MAXREDIAL {
+			wait = time.Second * MAXREDIAL
+		}
+	}
+
+	ws.subsMutex.Lock()
+	defer ws.subsMutex.Unlock()
+
+	ws.Lock()
+	defer ws.Unlock()
+
+	ws.connected = true
+	ws.reconnects++
+	ws.lastMsg = time.Now()
+
+	for _, chname := range ws.channels() {
+		ws.writeCommand("subscribe", channelsByName[chname])
+	}
+	return true
+}
+
+// Get names of the channels in use.
+// It must be called with the lock held.
+func (ws *WSClient) channels() (chnames []string) {
+	for chname := range ws.handles {
+		chnames = append(chnames, chname)
+	}
+	for chname := range ws.mergedSubs {
+		if len(ws.handles[chname]) == 0 {
+			chnames = append(chnames, chname)
+		}
+	}
+	return
+}
+
+// Get connection status.
+func (ws *WSClient) Status() WSStatus {
+	ws.Lock()
+	defer ws.Unlock()
+
+	return WSStatus{
+		Connected:   ws.connected,
+		Reconnects:  ws.reconnects,
+		LastMessage: ws.lastMsg,
+		Channels:    len(ws.channels()),
+	}
+}
+
 // Create handler.
 // If the message comes from the channels that are subscribed,
 // it is sent to the chans.
@@ -180,9 +393,13 @@ func (ws *WSClient) wsHandler() error {
 			return err
 		}
 
+		ws.Lock()
+		ws.lastMsg = time.Now()
+		ws.Unlock()
+
 		var imsg []interface{}
 		err = json.Unmarshal(msg, &imsg)
-		if err != nil || len(imsg) < 3 {
+		if err != nil || len(imsg) < 2 {
 			continue
 		}
 
@@ -192,38 +409,99 @@ func (ws *WSClient) wsHandler() error {
 		}
 
 		chid := int(arg)
+
+		// [<channel id>, 0] acknowledges unsubscription.
+		if len(imsg) == 2 {
+			if v, ok := imsg[1].(float64); ok && v == 0 {
+				ws.acknowledge(chid)
+			}
+			continue
+		}
+
 		args, ok := imsg[2].([]interface{})
 		if !ok {
 			continue
 		}
 
-		var wsupdate interface{}
+		chname := channelsByID[chid]
+
 		if chid == TICKER {
-			wsupdate, err = convertArgsToTicker(args)
+			wsticker, err := convertArgsToTicker(args)
 			if err != nil {
+				ws.report(chname, err)
 				continue
 			}
+			ws.dispatch(chname, wsticker)
+
 		} else if intInSlice(chid, marketChannels) {
-			wsupdate, err = convertArgsToMarketUpdate(args)
+			// valid updates of the batch are sent even if others fail.
+			updates, err := convertArgsToMarketUpdate(args, ws.DepthLimit())
 			if err != nil {
-				continue
+				ws.report(chname, err)
+			}
+			if len(updates) > 0 {
+				ws.dispatch(chname, updates)
 			}
-		} else {
-			continue
 		}
+	}
+}
 
-		chname := channelsByID[chid]
-		if ws.Subs[chname] != nil {
-			select {
-			case ws.Subs[chname] <- wsupdate:
-			default:
-			}
+// Get parse errors of received updates.
+// Errors are dropped when the channel is full.
+func (ws *WSClient) Errors() <-chan error {
+	return ws.errs
+}
+
+// Report parse error of channel update.
+func (ws *WSClient) report(chname string, err error) {
+	select {
+	case ws.errs <- Error(WSUpdateError, chname+": "+err.Error()):
+	default:
+	}
+}
+
+// Send update to the subscribers of channel.
+func (ws *WSClient) dispatch(chname string, wsupdate interface{}) {
+	ws.Lock()
+	defer ws.Unlock()
+
+	for _, sub := range ws.handles[chname] {
+		select {
+		case sub.ch <- wsupdate:
+		default:
+		}
+	}
+
+	if !ws.mergedSubs[chname] {
+		return
+	}
+
+	if updates, ok := wsupdate.([]MarketUpdate); ok {
+		select {
+		case ws.merged <- PairUpdate{Pair: chname, Updates: updates}:
+		default:
 		}
 	}
 }
 
+// Acknowledge unsubscription of channel.
+func (ws *WSClient) acknowledge(chid int) {
+	ws.Lock()
+	defer ws.Unlock()
+
+	if ack, ok := ws.acks[chid]; ok {
+		close(ack)
+		delete(ws.acks, chid)
+	}
+}
+
 // Convert ticker update arguments and fill wsticker.
 func convertArgsToTicker(args []interface{}) (wsticker WSTicker, err error) {
+	if len(args) < 10 {
+		err = Error(WSTickerError, "Update")
+		return
+	}
+
 	wsticker.Symbol = channelsByID[int(args[0].(float64))]
 	wsticker.Last, err = strconv.ParseFloat(args[1].(string), 64)
 	if err != nil {
@@ -286,173 +564,437 @@ func convertArgsToTicker(args []interface{}) (wsticker WSTicker, err error) {
 }
 
 // Convert market update arguments and fill marketupdate.
-func convertArgsToMarketUpdate(args []interface{}) (res []MarketUpdate, err error) {
-	res = make([]MarketUpdate, len(args))
-	for i, val := range args {
-		vals := val.([]interface{})
-		var marketupdate MarketUpdate
-
-		switch vals[0].(string) {
-		case "i":
-			var orderdepth OrderDepth
-			val := vals[1].(map[string]interface{})
-			orderdepth.Symbol = val["currencyPair"].(string)
-
-			asks := val["orderBook"].([]interface{})[0].(map[string]interface{})
-			bids := val["orderBook"].([]interface{})[1].(map[string]interface{})
-
-			for k, v := range bids {
-				price, _ := strconv.ParseFloat(k, 64)
-				quantity, _ := strconv.ParseFloat(v.(string), 64)
-				book := Book{Price: price, Quantity: quantity}
-				orderdepth.OrderBook.Bids = append(orderdepth.OrderBook.Bids, book)
+// depth limits the number of levels kept on each side of an
+// order depth snapshot, 0 keeps all of them.
+// Updates which can not be parsed are left out of res,
+// err is the first of their errors.
+func convertArgsToMarketUpdate(args []interface{}, depth int) (res []MarketUpdate, err error) {
+	res = make([]MarketUpdate, 0, len(args))
+	for _, val := range args {
+		marketupdate, uerr := convertMarketUpdate(val, depth)
+		if uerr != nil {
+			if err == nil {
+				err = uerr
 			}
+			continue
+		}
+		res = append(res, marketupdate)
+	}
+	return
+}
 
-			for k, v := range asks {
-				price, _ := strconv.ParseFloat(k, 64)
-				quantity, _ := strconv.ParseFloat(v.(string), 64)
-				book := Book{Price: price, Quantity: quantity}
-				orderdepth.OrderBook.Asks = append(orderdepth.OrderBook.Asks, book)
-			}
+// Convert one market update.
+func convertMarketUpdate(val interface{}, depth int) (marketupdate MarketUpdate, err error) {
+	vals, ok := val.([]interface{})
+	if !ok || len(vals) < 2 {
+		err = Error(MarketUpdateError, "Update")
+		return
+	}
 
-			marketupdate.TypeUpdate = "OrderDepth"
-			marketupdate.Data = orderdepth
+	kind, _ := vals[0].(string)
 
-		case "o":
-			var orderdatafield WSOrderBook
+	switch kind {
+	case "i":
+		var orderdepth OrderDepth
+		orderdepth, err = convertArgsToOrderDepth(vals[1], depth)
+		if err != nil {
+			return
+		}
 
-			if vals[3].(string) == "0.00000000" {
-				marketupdate.TypeUpdate = "OrderBookRemove"
-			} else {
-				marketupdate.TypeUpdate = "OrderBookModify"
-			}
+		marketupdate.TypeUpdate = OrderDepthUpdate
+		marketupdate.Data = orderdepth
 
-			if vals[1].(float64) == 1 {
-				orderdatafield.TypeOrder = "bid"
-			} else {
-				orderdatafield.TypeOrder = "ask"
-			}
+	case "o":
+		var orderdatafield WSOrderBook
 
-			orderdatafield.Rate, err = strconv.ParseFloat(vals[2].(string), 64)
-			if err != nil {
-				err = Error(WSOrderBookError, "Rate")
-				return
-			}
+		if len(vals) < 4 {
+			err = Error(WSOrderBookError, "Update")
+			return
+		}
 
-			orderdatafield.Amount, err = strconv.ParseFloat(vals[3].(string), 64)
-			if err != nil {
-				err = Error(WSOrderBookError, "Amount")
-				return
-			}
+		side, _ := vals[1].(float64)
+		rate, _ := vals[2].(string)
+		amount, _ := vals[3].(string)
 
-			marketupdate.Data = orderdatafield
+		if amount == "0.00000000" {
+			marketupdate.TypeUpdate = OrderBookRemoveUpdate
+		} else {
+			marketupdate.TypeUpdate = OrderBookModifyUpdate
+		}
 
-		case "t":
-			var tradedatafield NewTrade
+		if side == 1 {
+			orderdatafield.TypeOrder = SideBid
+		} else {
+			orderdatafield.TypeOrder = SideAsk
+		}
 
-			tradedatafield.TradeId, err = strconv.ParseInt(vals[1].(string), 10, 64)
-			if err != nil {
-				err = Error(NewTradeError, "TradeId")
-				return
-			}
+		orderdatafield.Rate, err = strconv.ParseFloat(rate, 64)
+		if err != nil {
+			err = Error(WSOrderBookError, "Rate")
+			return
+		}
 
-			if vals[2].(float64) == 1 {
-				tradedatafield.TypeOrder = "buy"
-			} else {
-				tradedatafield.TypeOrder = "sell"
-			}
+		orderdatafield.Amount, err = strconv.ParseFloat(amount, 64)
+		if err != nil {
+			err = Error(WSOrderBookError, "Amount")
+			return
+		}
 
-			tradedatafield.Rate, err = strconv.ParseFloat(vals[3].(string), 64)
-			if err != nil {
-				err = Error(NewTradeError, "Rate")
-				return
-			}
+		marketupdate.Data = orderdatafield
 
-			tradedatafield.Amount, err = strconv.ParseFloat(vals[4].(string), 64)
-			if err != nil {
-				err = Error(NewTradeError, "Amount")
-				return
-			}
+	case "t":
+		var tradedatafield NewTrade
+
+		if len(vals) < 6 {
+			err = Error(NewTradeError, "Update")
+			return
+		}
+
+		tradeID, _ := vals[1].(string)
+		side, _ := vals[2].(float64)
+		rate, _ := vals[3].(string)
+		amount, _ := vals[4].(string)
+
+		tradedatafield.TradeId, err = strconv.ParseInt(tradeID, 10, 64)
+		if err != nil {
+			err = Error(NewTradeError, "TradeId")
+			return
+		}
+
+		if side == 1 {
+			tradedatafield.TypeOrder = SideBuy
+		} else {
+			tradedatafield.TypeOrder = SideSell
+		}
+
+		tradedatafield.Rate, err = strconv.ParseFloat(rate, 64)
+		if err != nil {
+			err = Error(NewTradeError, "Rate")
+			return
+		}
+
+		tradedatafield.Amount, err = strconv.ParseFloat(amount, 64)
+		if err != nil {
+			err = Error(NewTradeError, "Amount")
+			return
+		}
+
+		tradedatafield.Total, ok = vals[5].(float64)
+		if !ok {
+			err = Error(NewTradeError, "Total")
+			return
+		}
+
+		marketupdate.TypeUpdate = NewTradeUpdate
+		marketupdate.Data = tradedatafield
+
+	default:
+		err = Error(MarketUpdateError, "Type")
+	}
+	return
+}
+
+// Convert order depth snapshot and fill orderdepth.
+// Bids are sorted by descending price and asks by ascending price.
+func convertArgsToOrderDepth(arg interface{}, depth int) (orderdepth OrderDepth, err error) {
+	val, ok := arg.(map[string]interface{})
+	if !ok {
+		err = Error(OrderDepthError, "Snapshot")
+		return
+	}
+
+	orderdepth.Symbol, ok = val["currencyPair"].(string)
+	if !ok {
+		err = Error(OrderDepthError, "Symbol")
+		return
+	}
+
+	sides, ok := val["orderBook"].([]interface{})
+	if !ok || len(sides) < 2 {
+		err = Error(OrderDepthError, "OrderBook")
+		return
+	}
+
+	asks, ok := sides[0].(map[string]interface{})
+	if !ok {
+		err = Error(OrderDepthError, "Asks")
+		return
+	}
+
+	bids, ok := sides[1].(map[string]interface{})
+	if !ok {
+		err = Error(OrderDepthError, "Bids")
+		return
+	}
+
+	orderdepth.OrderBook.Asks, err = convertBookSide(asks, false, depth)
+	if err != nil {
+		return
+	}
+
+	orderdepth.OrderBook.Bids, err = convertBookSide(bids, true, depth)
+	return
+}
 
-			tradedatafield.Total = vals[5].(float64)
+// Convert one side of order depth snapshot.
+// Books are sorted by price, descending if desc is true,
+// and cut to the first depth levels if depth is positive.
+func convertBookSide(side map[string]interface{}, desc bool, depth int) (books []Book, err error) {
+	books = make([]Book, 0, len(side))
+	for k, v := range side {
+		price, err := strconv.ParseFloat(k, 64)
+		if err != nil {
+			return nil, Error(OrderDepthError, "Price")
+		}
 
-			marketupdate.TypeUpdate = "NewTrade"
-			marketupdate.Data = tradedatafield
+		s, ok := v.(string)
+		if !ok {
+			return nil, Error(OrderDepthError, "Quantity")
 		}
 
-		res[i] = marketupdate
+		quantity, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, Error(OrderDepthError, "Quantity")
+		}
+
+		books = append(books, Book{Price: price, Quantity: quantity})
+	}
+
+	sort.Slice(books, func(i, j int) bool {
+		if desc {
+			return books[i].Price > books[j].Price
+		}
+		return books[i].Price < books[j].Price
+	})
+
+	// copy the top levels, so the rest of the book can be released.
+	if depth > 0 && len(books) > depth {
+		books = append([]Book(nil), books[:depth]...)
 	}
-	return res, nil
+	return
+}
+
+// Write subscribe or unsubscribe command for channel id.
+func (ws *WSClient) writeCommand(command string, chid int) error {
+	msg, _ := subscription{
+		Command: command,
+		Channel: strconv.Itoa(chid),
+	}.toJSON()
+
+	return ws.writeMessage(msg)
+}
+
+// Check whether channel has any subscriber.
+// It must be called with the lock held.
+func (ws *WSClient) inUse(chname string) bool {
+	return len(ws.handles[chname]) > 0 || ws.mergedSubs[chname]
 }
 
 // sub-function for subscription.
-func (ws *WSClient) subscribe(chid int, chname string) (err error) {
+// The subscribe command is sent only for the first subscriber of the channel.
+func (ws *WSClient) subscribe(chid int, chname string) (sub *Subscription, err error) {
+	ws.subsMutex.Lock()
+	defer ws.subsMutex.Unlock()
+
+	return ws.addHandle(chid, chname, make(chan interface{}, SUBSBUFFER), false)
+}
+
+// Add subscription handle sending to ch.
+// It must be called with subsMutex held.
+func (ws *WSClient) addHandle(chid int, chname string, ch chan interface{}, keep bool) (sub *Subscription, err error) {
 	ws.Lock()
 	defer ws.Unlock()
 
-	//	if ws.Subs[chname] != nil {
-	//		err = Error(SubscribeError)
-	//		return
-	//	}
-	//
-	//	ws.Subs[chname] = make(chan interface{}, SUBSBUFFER)
+	if ws.closed {
+		err = Error(WSClosedError)
+		return
+	}
 
-	if ws.Subs[chname] == nil {
-		ws.Subs[chname] = make(chan interface{}, SUBSBUFFER)
+	if !ws.inUse(chname) {
+		err = ws.writeCommand("subscribe", chid)
+		if err != nil {
+			return
+		}
 	}
 
-	subsMsg, _ := subscription{
-		Command: "subscribe",
-		Channel: strconv.Itoa(chid),
-	}.toJSON()
+	sub = &Subscription{
+		C:       ch,
+		Channel: chname,
+		ch:      ch,
+		ws:      ws,
+		keep:    keep,
+	}
+	ws.handles[chname] = append(ws.handles[chname], sub)
+	return
+}
 
-	err = ws.writeMessage(subsMsg)
+// sub-function for unsubscription.
+// It sends the unsubscribe command and waits for server acknowledgement.
+// It must be called with subsMutex held.
+func (ws *WSClient) unsubscribe(chid int) (err error) {
+	ack := make(chan struct{})
+
+	ws.Lock()
+	ws.acks[chid] = ack
+	ws.Unlock()
+
+	defer func() {
+		ws.Lock()
+		delete(ws.acks, chid)
+		ws.Unlock()
+	}()
+
+	err = ws.writeCommand("unsubscribe", chid)
 	if err != nil {
 		return
 	}
 
+	select {
+	case <-ack:
+	case <-time.After(time.Second * ACKTIMEOUT):
+		err = Error(UnsubscribeError, channelsByID[chid])
+	}
 	return
 }
 
-// sub-function for unsubscription.
-// the chans are not closed once the subscription is made to protect chan address.
-// To prevent chans taking a new address on the memory, thus chans can be used repeatedly.
-func (ws *WSClient) unsubscribe(chname string) (err error) {
+// Remove handle and close its channel.
+// It reports whether the channel has no subscriber left.
+func (s *Subscription) release() (last, ok bool) {
+	ws := s.ws
+
 	ws.Lock()
 	defer ws.Unlock()
 
-	if ws.Subs[chname] == nil {
+	if s.closed {
 		return
 	}
 
-	unSubsMsg, _ := subscription{
-		Command: "unsubscribe",
-		Channel: chname,
-	}.toJSON()
+	s.closed = true
+	if !s.keep {
+		close(s.ch)
+	}
 
-	err = ws.writeMessage(unSubsMsg)
+	handles := ws.handles[s.Channel]
+	for i, h := range handles {
+		if h == s {
+			handles = append(handles[:i], handles[i+1:]...)
+			break
+		}
+	}
+
+	if len(handles) == 0 {
+		delete(ws.handles, s.Channel)
+	} else {
+		ws.handles[s.Channel] = handles
+	}
+
+	return !ws.inUse(s.Channel), true
+}
+
+// Unsubscribe and close the subscription channel.
+// The unsubscribe command is sent when the last subscriber of the
+// channel leaves. It returns nil if successful or already unsubscribed.
+func (s *Subscription) Unsubscribe() error {
+	s.ws.subsMutex.Lock()
+	defer s.ws.subsMutex.Unlock()
+
+	return s.unsubscribe()
+}
+
+// sub-function for Unsubscribe.
+// It must be called with subsMutex held.
+func (s *Subscription) unsubscribe() error {
+	last, ok := s.release()
+	if !ok || !last {
+		return nil
+	}
+	return s.ws.unsubscribe(channelsByName[s.Channel])
+}
+
+// Close the subscription channel without unsubscribing on the server.
+// It is used when the connection is lost, the channel is not
+// subscribed again on reconnection if it has no subscriber left.
+func (s *Subscription) drop() {
+	s.ws.subsMutex.Lock()
+	defer s.ws.subsMutex.Unlock()
+
+	s.release()
+}
+
+// Subscribe to ticker or market channel and get a new handle.
+// Each call returns an independent handle with its own channel.
+func (ws *WSClient) Subscribe(chname string) (*Subscription, error) {
+	chname = strings.ToUpper(chname)
+	chid, ok := channelsByName[chname]
+	if !ok {
+		return nil, Error(ChannelError, chname)
+	}
+	return ws.subscribe(chid, chname)
+}
+
+// sub-function for subscriptions in Subs map.
+// The chan of Subs map is created once and kept on unsubscription,
+// so it can be used again after subscribing to the channel again.
+func (ws *WSClient) subscribeSubs(chid int, chname string) error {
+	ws.subsMutex.Lock()
+	defer ws.subsMutex.Unlock()
+
+	ws.Lock()
+	_, ok := ws.subs[chname]
+	ch := ws.Subs[chname]
+	if ch == nil {
+		ch = make(chan interface{}, SUBSBUFFER)
+		ws.Subs[chname] = ch
+	}
+	ws.Unlock()
+
+	if ok {
+		return nil
+	}
+
+	sub, err := ws.addHandle(chid, chname, ch, true)
 	if err != nil {
 		return err
 	}
 
-	// close(ws.Subs[chname])
-	// delete(ws.Subs, chname)
-	return
+	ws.Lock()
+	ws.subs[chname] = sub
+	ws.Unlock()
+	return nil
+}
+
+// sub-function for unsubscriptions in Subs map.
+// The chan is not closed and stays in Subs map.
+func (ws *WSClient) unsubscribeSubs(chname string) error {
+	ws.subsMutex.Lock()
+	defer ws.subsMutex.Unlock()
+
+	ws.Lock()
+	sub, ok := ws.subs[chname]
+	delete(ws.subs, chname)
+	ws.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return sub.unsubscribe()
 }
 
 // Subscribe to ticker channel.
+// Updates are sent to Subs["TICKER"].
 // It returns nil if successful.
 func (ws *WSClient) SubscribeTicker() error {
-	return (ws.subscribe(TICKER, "TICKER"))
+	return ws.subscribeSubs(TICKER, "TICKER")
 }
 
 // Unsubscribe from ticker channel.
 // It returns nil if successful.
 func (ws *WSClient) UnsubscribeTicker() error {
-	return (ws.unsubscribe("TICKER"))
+	return ws.unsubscribeSubs("TICKER")
 }
 
 // Subscribe to market channel.
+// Updates are sent to Subs[market].
 // It returns nil if successful.
 func (ws *WSClient) SubscribeMarket(chname string) error {
 	chname = strings.ToUpper(chname)
@@ -460,7 +1002,7 @@ func (ws *WSClient) SubscribeMarket(chname string) error {
 	if !ok {
 		return Error(ChannelError, chname)
 	}
-	return (ws.subscribe(chid, chname))
+	return ws.subscribeSubs(chid, chname)
 }
 
 // Unsubscribe from market channel.
@@ -471,5 +1013,105 @@ func (ws *WSClient) UnsubscribeMarket(chname string) error {
 	if !ok {
 		return Error(ChannelError, chname)
 	}
-	return (ws.unsubscribe(chname))
+	return ws.unsubscribeSubs(chname)
+}
+
+// Subscribe to market channels with one merged stream.
+// Each update is tagged with its market name.
+// Unknown markets are skipped and reported in the returned error,
+// the stream is still returned for the rest of them.
+func (ws *WSClient) SubscribeMarkets(chnames ...string) (<-chan PairUpdate, error) {
+	var unknown []string
+
+	ws.subsMutex.Lock()
+	defer ws.subsMutex.Unlock()
+
+	ws.Lock()
+	defer ws.Unlock()
+
+	if ws.closed {
+		return ws.merged, Error(WSClosedError)
+	}
+
+	for _, chname := range chnames {
+		chname = strings.ToUpper(chname)
+		chid, ok := channelsByName[chname]
+		if !ok || chid == TICKER {
+			unknown = append(unknown, chname)
+			continue
+		}
+
+		if ws.mergedSubs[chname] {
+			continue
+		}
+
+		if !ws.inUse(chname) {
+			if err := ws.writeCommand("subscribe", chid); err != nil {
+				return ws.merged, err
+			}
+		}
+		ws.mergedSubs[chname] = true
+	}
+
+	if len(unknown) > 0 {
+		return ws.merged, Error(ChannelsError, strings.Join(unknown, ", "))
+	}
+	return ws.merged, nil
+}
+
+// Subscribe to all market channels with one merged stream.
+func (ws *WSClient) SubscribeAllMarkets() (<-chan PairUpdate, error) {
+	var chnames []string
+	for _, chid := range marketChannels {
+		chnames = append(chnames, channelsByID[chid])
+	}
+	return ws.SubscribeMarkets(chnames...)
+}
+
+// Unsubscribe market channels from merged stream.
+// Markets that also have other subscribers keep running.
+// Unknown markets are skipped and reported in the returned error.
+func (ws *WSClient) UnsubscribeMarkets(chnames ...string) error {
+	var unknown []string
+
+	ws.subsMutex.Lock()
+	defer ws.subsMutex.Unlock()
+
+	for _, chname := range chnames {
+		chname = strings.ToUpper(chname)
+		chid, ok := channelsByName[chname]
+		if !ok || chid == TICKER {
+			unknown = append(unknown, chname)
+			continue
+		}
+
+		ws.Lock()
+		merged := ws.mergedSubs[chname]
+		delete(ws.mergedSubs, chname)
+		last := merged && !ws.inUse(chname)
+		ws.Unlock()
+
+		if last {
+			if err := ws.unsubscribe(chid); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(unknown) > 0 {
+		return Error(ChannelsError, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Unsubscribe all market channels from merged stream.
+func (ws *WSClient) UnsubscribeAllMarkets() error {
+	ws.Lock()
+	var chnames []string
+	for chname := range ws.mergedSubs {
+		chnames = append(chnames, chname)
+	}
+	ws.Unlock()
+
+	return ws.UnsubscribeMarkets(chnames...)
 }
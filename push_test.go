@@ -0,0 +1,48 @@
+package poloniex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvertBookSide(t *testing.T) {
+	side := map[string]interface{}{
+		"0.10": "1",
+		"0.30": "3",
+		"0.20": "2",
+		"0.40": "4",
+	}
+
+	tests := []struct {
+		name  string
+		side  map[string]interface{}
+		desc  bool
+		depth int
+		want  []Book
+		ok    bool
+	}{
+		{"asks", side, false, 0, []Book{{0.1, 1}, {0.2, 2}, {0.3, 3}, {0.4, 4}}, true},
+		{"bids", side, true, 0, []Book{{0.4, 4}, {0.3, 3}, {0.2, 2}, {0.1, 1}}, true},
+		{"asks limited", side, false, 2, []Book{{0.1, 1}, {0.2, 2}}, true},
+		{"bids limited", side, true, 3, []Book{{0.4, 4}, {0.3, 3}, {0.2, 2}}, true},
+		{"limit above size", side, false, 10, []Book{{0.1, 1}, {0.2, 2}, {0.3, 3}, {0.4, 4}}, true},
+		{"empty", map[string]interface{}{}, false, 2, []Book{}, true},
+		{"bad price", map[string]interface{}{"x": "1"}, false, 0, nil, false},
+		{"bad quantity", map[string]interface{}{"0.1": "x"}, false, 0, nil, false},
+		{"quantity not string", map[string]interface{}{"0.1": 1.0}, false, 0, nil, false},
+	}
+
+	for _, tt := range tests {
+		got, err := convertBookSide(tt.side, tt.desc, tt.depth)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: error = %v, want ok %v", tt.name, err, tt.ok)
+			continue
+		}
+		if tt.ok && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		if tt.depth > 0 && cap(got) > tt.depth {
+			t.Errorf("%s: capacity %d keeps levels beyond depth %d", tt.name, cap(got), tt.depth)
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package poloniex
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DAYSECONDS = 86400 // Seconds Of A Trades Store File
+
+// Time range [Start, End] in seconds covered by a store file.
+type storedRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+type candleFile struct {
+	Ranges  []storedRange `json:"ranges"`
+	Candles []CandleStick `json:"candles"`
+}
+
+type tradeFile struct {
+	Ranges    []storedRange `json:"ranges"`
+	Trades    []PublicTrade `json:"trades"`
+	Truncated []int64       `json:"truncated,omitempty"` // one second windows cut at the page size
+}
+
+// Local cache of chart data and public trades.
+// Candles of every market and period are kept in one json file under the
+// store directory, trades of every market in one json file per UTC day,
+// together with the time ranges already fetched. Only the missing ranges
+// are requested from the exchange, candles and trades which may still
+// change are never marked as fetched.
+type HistoryStore struct {
+	client *Poloniex
+	dir    string
+	sync.Mutex
+}
+
+// Create history store in directory dir.
+func NewHistoryStore(client *Poloniex, dir string) (*HistoryStore, error) {
+	for _, sub := range []string{"candles", "trades"} {
+		err := os.MkdirAll(filepath.Join(dir, sub), 0755)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &HistoryStore{client: client, dir: dir}, nil
+}
+
+// Get candles of market between start and end, see GetChartRange.
+// Missing candles of the result are reported as gaps.
+func (s *HistoryStore) Candles(market string, start, end time.Time, period Period) (candles []CandleStick, gaps []ChartGap, err error) {
+	if !period.valid() {
+		err = Error(PeriodError)
+		return
+	}
+
+	if now := time.Now(); end.After(now) {
+		end = now
+	}
+
+	if start.IsZero() || end.IsZero() || !start.Before(end) {
+		err = Error(TimeError)
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	market = strings.ToUpper(market)
+	path := filepath.Join(s.dir, "candles", fmt.Sprintf("%s_%d.json", market, period))
+
+	var file candleFile
+	err = readStoreFile(path, &file)
+	if err != nil {
+		return
+	}
+
+	step := int64(period)
+	missing := missingRanges(file.Ranges, start.Unix(), end.Unix())
+
+	for _, r := range missing {
+		first, last := alignRange(r, step)
+		if first <= last {
+			var fetched []CandleStick
+			fetched, err = s.client.chartRange(market, first, last, period)
+			if err != nil {
+				return
+			}
+			file.Candles = mergeCandles(file.Candles, fetched)
+		}
+
+		// the last candle is complete once its period is over.
+		if complete := time.Now().Unix() - step; r.End > complete {
+			r.End = complete
+		}
+		if r.End >= r.Start {
+			file.Ranges = addRange(file.Ranges, r)
+		}
+	}
+
+	if len(missing) > 0 {
+		err = writeStoreFile(path, file)
+		if err != nil {
+			return
+		}
+	}
+
+	for _, v := range file.Candles {
+		if date := v.Date.Unix(); date >= start.Unix() && date <= end.Unix() {
+			candles = append(candles, v)
+		}
+	}
+
+	first, last := alignRange(storedRange{Start: start.Unix(), End: end.Unix()}, step)
+	gaps = chartGaps(candles, first, last, step)
+	return
+}
+
+// Get the first and last candle dates of range.
+func alignRange(r storedRange, step int64) (first, last int64) {
+	first = r.Start
+	if rem := first % step; rem != 0 {
+		first += step - rem
+	}
+	last = r.End - r.End%step
+	return
+}
+
+// Get public trades of market between start and end in chronological
+// order, see GetPublicTradeRange.
+// Holes in the sequential trade ids of the result are reported as gaps,
+// followed by the truncated one second windows of StreamPublicTrades.
+// Truncated windows are stored as fetched, they are never requested again.
+func (s *HistoryStore) Trades(market string, start, end time.Time) (trades []PublicTrade, gaps []TradeGap, err error) {
+	if now := time.Now(); end.After(now) {
+		end = now
+	}
+
+	if start.IsZero() || end.IsZero() || !start.Before(end) {
+		err = Error(TimeError)
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	market = strings.ToUpper(market)
+	dir := filepath.Join(s.dir, "trades", market)
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return
+	}
+
+	// day files of the window and the ranges missing in them.
+	first := start.Unix() - start.Unix()%DAYSECONDS
+	files := make(map[int64]*tradeFile)
+	var missing []storedRange
+
+	for day := first; day <= end.Unix(); day += DAYSECONDS {
+		file := new(tradeFile)
+		err = readStoreFile(tradePath(dir, day), file)
+		if err != nil {
+			return
+		}
+		files[day] = file
+
+		r := clipRange(storedRange{Start: start.Unix(), End: end.Unix()}, day)
+		for _, v := range missingRanges(file.Ranges, r.Start, r.End) {
+			missing = addRange(missing, v)
+		}
+	}
+
+	changed := make(map[int64]bool)
+	var fetchErr error
+
+	for _, r := range missing {
+		var fetched []PublicTrade
+		var truncated []int64
+		var last uint64
+
+		collect := func(v PublicTrade) error {
+			fetched = append(fetched, v)
+			return nil
+		}
+		fetchErr = s.client.streamPublicTrades(market, r.Start, r.End, &last, &truncated, collect, nil)
+
+		// trades fetched before an error are kept, their range is not.
+		byDay := make(map[int64][]PublicTrade)
+		for _, v := range fetched {
+			day := v.Date.Unix() - v.Date.Unix()%DAYSECONDS
+			byDay[day] = append(byDay[day], v)
+		}
+		for day, v := range byDay {
+			if file, ok := files[day]; ok {
+				file.Trades = mergeTrades(file.Trades, v)
+				changed[day] = true
+			}
+		}
+
+		for _, v := range truncated {
+			if file, ok := files[v-v%DAYSECONDS]; ok {
+				file.Truncated = addSecond(file.Truncated, v)
+				changed[v-v%DAYSECONDS] = true
+			}
+		}
+
+		if fetchErr != nil {
+			break
+		}
+
+		// trades of the current second may still arrive.
+		if complete := time.Now().Unix() - 1; r.End > complete {
+			r.End = complete
+		}
+		for day := r.Start - r.Start%DAYSECONDS; day <= r.End; day += DAYSECONDS {
+			file := files[day]
+			file.Ranges = addRange(file.Ranges, clipRange(r, day))
+			changed[day] = true
+		}
+	}
+
+	for day := range changed {
+		err = writeStoreFile(tradePath(dir, day), files[day])
+		if err != nil {
+			return
+		}
+	}
+
+	if fetchErr != nil {
+		err = fetchErr
+		return
+	}
+
+	for day := first; day <= end.Unix(); day += DAYSECONDS {
+		for _, v := range files[day].Trades {
+			if date := v.Date.Unix(); date >= start.Unix() && date <= end.Unix() {
+				trades = append(trades, v)
+			}
+		}
+	}
+
+	gaps = tradeGaps(trades)
+
+	for day := first; day <= end.Unix(); day += DAYSECONDS {
+		for _, v := range files[day].Truncated {
+			if v >= start.Unix() && v <= end.Unix() {
+				gaps = append(gaps, TradeGap{Truncated: time.Unix(v, 0).UTC()})
+			}
+		}
+	}
+	return
+}
+
+// Add second to sorted seconds unless it is there.
+func addSecond(seconds []int64, sec int64) []int64 {
+	i := sort.Search(len(seconds), func(i int) bool {
+		return seconds[i] >= sec
+	})
+	if i < len(seconds) && seconds[i] == sec {
+		return seconds
+	}
+
+	seconds = append(seconds, 0)
+	copy(seconds[i+1:], seconds[i:])
+	seconds[i] = sec
+	return seconds
+}
+
+// Get path of the trades file of day.
+func tradePath(dir string, day int64) string {
+	return filepath.Join(dir, time.Unix(day, 0).UTC().Format("2006-01-02")+".json")
+}
+
+// Get the part of range within day.
+func clipRange(r storedRange, day int64) storedRange {
+	if r.Start < day {
+		r.Start = day
+	}
+	if last := day + DAYSECONDS - 1; r.End > last {
+		r.End = last
+	}
+	return r
+}
+
+// Remove the cached candles and trades of market.
+func (s *HistoryStore) Clear(market string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	market = strings.ToUpper(market)
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "candles", market+"_*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.RemoveAll(filepath.Join(s.dir, "trades", market))
+}
+
+// Read store file, a missing file is an empty one.
+func readStoreFile(path string, v interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// Write store file through a temporary file,
+// so an interrupted write never leaves a broken file.
+func writeStoreFile(path string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	err = ioutil.WriteFile(tmp, b, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Get the parts of [start, end] not covered by ranges.
+// Ranges are sorted and do not overlap.
+func missingRanges(ranges []storedRange, start, end int64) (missing []storedRange) {
+	for _, r := range ranges {
+		if r.End < start {
+			continue
+		}
+		if r.Start > end {
+			break
+		}
+		if r.Start > start {
+			missing = append(missing, storedRange{Start: start, End: r.Start - 1})
+		}
+		start = r.End + 1
+	}
+
+	if start <= end {
+		missing = append(missing, storedRange{Start: start, End: end})
+	}
+	return
+}
+
+// Add range to sorted ranges, merging overlapping and adjacent ones.
+func addRange(ranges []storedRange, r storedRange) (merged []storedRange) {
+	ranges = append(ranges, r)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	for _, v := range ranges {
+		n := len(merged)
+		if n > 0 && v.Start <= merged[n-1].End+1 {
+			if v.End > merged[n-1].End {
+				merged[n-1].End = v.End
+			}
+			continue
+		}
+		merged = append(merged, v)
+	}
+	return
+}
+
+// Merge candles by date, fetched candles replace stored ones.
+func mergeCandles(stored, fetched []CandleStick) []CandleStick {
+	byDate := make(map[int64]CandleStick, len(stored)+len(fetched))
+	for _, v := range stored {
+		byDate[v.Date.Unix()] = v
+	}
+	for _, v := range fetched {
+		byDate[v.Date.Unix()] = v
+	}
+
+	candles := make([]CandleStick, 0, len(byDate))
+	for _, v := range byDate {
+		candles = append(candles, v)
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Date.Before(candles[j].Date.Time)
+	})
+	return candles
+}
+
+// Merge trades by trade id in chronological order.
+func mergeTrades(stored, fetched []PublicTrade) []PublicTrade {
+	byID := make(map[uint64]PublicTrade, len(stored)+len(fetched))
+	for _, v := range stored {
+		byID[v.TradeID] = v
+	}
+	for _, v := range fetched {
+		byID[v.TradeID] = v
+	}
+
+	trades := make([]PublicTrade, 0, len(byID))
+	for _, v := range byID {
+		trades = append(trades, v)
+	}
+
+	sort.Slice(trades, func(i, j int) bool {
+		return trades[i].TradeID < trades[j].TradeID
+	})
+	return trades
+}
@@ -0,0 +1,144 @@
+package poloniex
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMissingRanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		ranges     []storedRange
+		start, end int64
+		want       []storedRange
+	}{
+		{"empty store", nil, 10, 20, []storedRange{{10, 20}}},
+		{"covered", []storedRange{{0, 100}}, 10, 20, nil},
+		{"exact", []storedRange{{10, 20}}, 10, 20, nil},
+		{"before", []storedRange{{0, 5}}, 10, 20, []storedRange{{10, 20}}},
+		{"after", []storedRange{{30, 40}}, 10, 20, []storedRange{{10, 20}}},
+		{"head covered", []storedRange{{0, 14}}, 10, 20, []storedRange{{15, 20}}},
+		{"tail covered", []storedRange{{15, 30}}, 10, 20, []storedRange{{10, 14}}},
+		{"middle covered", []storedRange{{12, 14}}, 10, 20, []storedRange{{10, 11}, {15, 20}}},
+		{"holes", []storedRange{{10, 11}, {14, 15}, {19, 25}}, 10, 20, []storedRange{{12, 13}, {16, 18}}},
+		{"single second", nil, 10, 10, []storedRange{{10, 10}}},
+	}
+
+	for _, tt := range tests {
+		got := missingRanges(tt.ranges, tt.start, tt.end)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: missingRanges(%v, %d, %d) = %v, want %v", tt.name, tt.ranges, tt.start, tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestAddRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []storedRange
+		r      storedRange
+		want   []storedRange
+	}{
+		{"empty", nil, storedRange{10, 20}, []storedRange{{10, 20}}},
+		{"disjoint before", []storedRange{{30, 40}}, storedRange{10, 20}, []storedRange{{10, 20}, {30, 40}}},
+		{"disjoint after", []storedRange{{0, 5}}, storedRange{10, 20}, []storedRange{{0, 5}, {10, 20}}},
+		{"adjacent", []storedRange{{0, 9}}, storedRange{10, 20}, []storedRange{{0, 20}}},
+		{"overlapping", []storedRange{{0, 15}}, storedRange{10, 20}, []storedRange{{0, 20}}},
+		{"contained", []storedRange{{0, 30}}, storedRange{10, 20}, []storedRange{{0, 30}}},
+		{"bridging", []storedRange{{0, 9}, {21, 30}}, storedRange{10, 20}, []storedRange{{0, 30}}},
+		{"one second gap", []storedRange{{0, 8}}, storedRange{10, 20}, []storedRange{{0, 8}, {10, 20}}},
+	}
+
+	for _, tt := range tests {
+		ranges := append([]storedRange(nil), tt.ranges...)
+		got := addRange(ranges, tt.r)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: addRange(%v, %v) = %v, want %v", tt.name, tt.ranges, tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestClipRange(t *testing.T) {
+	const day = 10 * DAYSECONDS
+
+	tests := []struct {
+		name string
+		r    storedRange
+		want storedRange
+	}{
+		{"within day", storedRange{day + 10, day + 20}, storedRange{day + 10, day + 20}},
+		{"starts before", storedRange{day - 10, day + 20}, storedRange{day, day + 20}},
+		{"ends after", storedRange{day + 10, day + 2*DAYSECONDS}, storedRange{day + 10, day + DAYSECONDS - 1}},
+		{"spans day", storedRange{0, day * 2}, storedRange{day, day + DAYSECONDS - 1}},
+	}
+
+	for _, tt := range tests {
+		if got := clipRange(tt.r, day); got != tt.want {
+			t.Errorf("%s: clipRange(%v) = %v, want %v", tt.name, tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestAlignRange(t *testing.T) {
+	tests := []struct {
+		r           storedRange
+		step        int64
+		first, last int64
+	}{
+		{storedRange{0, 900}, 300, 0, 900},
+		{storedRange{1, 899}, 300, 300, 600},
+		{storedRange{301, 599}, 300, 600, 300},
+		{storedRange{300, 300}, 300, 300, 300},
+	}
+
+	for _, tt := range tests {
+		first, last := alignRange(tt.r, tt.step)
+		if first != tt.first || last != tt.last {
+			t.Errorf("alignRange(%v, %d) = %d, %d, want %d, %d", tt.r, tt.step, first, last, tt.first, tt.last)
+		}
+	}
+}
+
+func TestTradePath(t *testing.T) {
+	got := tradePath("trades", 20000*DAYSECONDS)
+	if want := filepath.Join("trades", "2024-10-04.json"); got != want {
+		t.Errorf("tradePath = %q, want %q", got, want)
+	}
+}
+
+func TestAddSecond(t *testing.T) {
+	tests := []struct {
+		seconds []int64
+		sec     int64
+		want    []int64
+	}{
+		{nil, 5, []int64{5}},
+		{[]int64{1, 9}, 5, []int64{1, 5, 9}},
+		{[]int64{1, 5, 9}, 5, []int64{1, 5, 9}},
+		{[]int64{5}, 1, []int64{1, 5}},
+		{[]int64{5}, 9, []int64{5, 9}},
+	}
+
+	for _, tt := range tests {
+		seconds := append([]int64(nil), tt.seconds...)
+		if got := addSecond(seconds, tt.sec); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("addSecond(%v, %d) = %v, want %v", tt.seconds, tt.sec, got, tt.want)
+		}
+	}
+}
+
+func TestMergeTrades(t *testing.T) {
+	stored := []PublicTrade{{TradeID: 1}, {TradeID: 3}}
+	fetched := []PublicTrade{{TradeID: 4}, {TradeID: 2}, {TradeID: 3}}
+
+	got := mergeTrades(stored, fetched)
+
+	var ids []uint64
+	for _, v := range got {
+		ids = append(ids, v.TradeID)
+	}
+	if want := []uint64{1, 2, 3, 4}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("mergeTrades ids = %v, want %v", ids, want)
+	}
+}
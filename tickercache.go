@@ -0,0 +1,239 @@
+package poloniex
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Cached ticker with the time of its last update.
+type CachedTicker struct {
+	Ticker
+	Symbol  string    `json:"symbol"`
+	Updated time.Time `json:"updated"`
+}
+
+// Ticker cache.
+// It is seeded from GetTickers and kept current by the push ticker channel.
+type TickerCache struct {
+	client   *Poloniex
+	sub      *Subscription
+	tickers  map[string]CachedTicker
+	watchers map[*TickerWatch]bool
+	sync.RWMutex
+}
+
+// Ticker change subscription of the cache.
+type TickerWatch struct {
+	C     <-chan CachedTicker // changes, closed by Stop
+	ch    chan CachedTicker
+	pairs map[string]bool // watched pairs, all if empty
+	cache *TickerCache
+}
+
+// Convert push ticker to REST ticker.
+func (t WSTicker) ToTicker() Ticker {
+	var isFrozen int
+	if t.IsFrozen {
+		isFrozen = 1
+	}
+
+	return Ticker{
+		ID:            channelsByName[t.Symbol],
+		Last:          decimal.NewFromFloat(t.Last),
+		LowestAsk:     decimal.NewFromFloat(t.LowestAsk),
+		HighestBid:    decimal.NewFromFloat(t.HighestBid),
+		PercentChange: decimal.NewFromFloat(t.PercentChange),
+		BaseVolume:    decimal.NewFromFloat(t.BaseVolume),
+		QuoteVolume:   decimal.NewFromFloat(t.QuoteVolume),
+		IsFrozen:      isFrozen,
+		High24hr:      decimal.NewFromFloat(t.High24hr),
+		Low24hr:       decimal.NewFromFloat(t.Low24hr),
+	}
+}
+
+// Convert REST ticker to push ticker.
+func (t Ticker) ToWSTicker(symbol string) WSTicker {
+	last, _ := t.Last.Float64()
+	lowestAsk, _ := t.LowestAsk.Float64()
+	highestBid, _ := t.HighestBid.Float64()
+	percentChange, _ := t.PercentChange.Float64()
+	baseVolume, _ := t.BaseVolume.Float64()
+	quoteVolume, _ := t.QuoteVolume.Float64()
+	high24hr, _ := t.High24hr.Float64()
+	low24hr, _ := t.Low24hr.Float64()
+
+	return WSTicker{
+		Symbol:        strings.ToUpper(symbol),
+		Last:          last,
+		LowestAsk:     lowestAsk,
+		HighestBid:    highestBid,
+		PercentChange: percentChange,
+		BaseVolume:    baseVolume,
+		QuoteVolume:   quoteVolume,
+		IsFrozen:      t.IsFrozen != 0,
+		High24hr:      high24hr,
+		Low24hr:       low24hr,
+	}
+}
+
+// Create new ticker cache.
+// The cache is seeded with client and updated from the ticker channel of ws.
+func NewTickerCache(client *Poloniex, ws *WSClient) (tc *TickerCache, err error) {
+	tc = &TickerCache{
+		client:   client,
+		tickers:  make(map[string]CachedTicker),
+		watchers: make(map[*TickerWatch]bool),
+	}
+
+	if err = tc.Refresh(); err != nil {
+		return nil, err
+	}
+
+	tc.sub, err = ws.Subscribe("TICKER")
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for update := range tc.sub.C {
+			if wsticker, ok := update.(WSTicker); ok {
+				tc.set(wsticker.Symbol, wsticker.ToTicker(), time.Now())
+			}
+		}
+	}()
+	return
+}
+
+// Seed the cache again from GetTickers.
+// Only missing tickers and those not updated since the request was sent
+// are replaced, they are stamped with the time of the request.
+func (tc *TickerCache) Refresh() error {
+	fetched := time.Now()
+	tickers, err := tc.client.GetTickers()
+	if err != nil {
+		return err
+	}
+
+	tc.Lock()
+	defer tc.Unlock()
+
+	for symbol, ticker := range tickers {
+		if cached, ok := tc.tickers[symbol]; ok && !cached.Updated.Before(fetched) {
+			continue
+		}
+		tc.store(symbol, ticker, fetched)
+	}
+	return nil
+}
+
+// Store ticker and notify watchers.
+func (tc *TickerCache) set(symbol string, ticker Ticker, updated time.Time) {
+	tc.Lock()
+	defer tc.Unlock()
+
+	tc.store(symbol, ticker, updated)
+}
+
+// sub-function for storing ticker.
+// It must be called with the lock held.
+func (tc *TickerCache) store(symbol string, ticker Ticker, updated time.Time) {
+	cached := CachedTicker{Ticker: ticker, Symbol: symbol, Updated: updated}
+	tc.tickers[symbol] = cached
+
+	for w := range tc.watchers {
+		if len(w.pairs) > 0 && !w.pairs[symbol] {
+			continue
+		}
+
+		select {
+		case w.ch <- cached:
+		default:
+		}
+	}
+}
+
+// Get cached ticker of pair.
+// It reports false if the pair is not in the cache.
+func (tc *TickerCache) Get(pair string) (CachedTicker, bool) {
+	tc.RLock()
+	defer tc.RUnlock()
+
+	cached, ok := tc.tickers[strings.ToUpper(pair)]
+	return cached, ok
+}
+
+// Get a copy of all cached tickers.
+func (tc *TickerCache) All() map[string]CachedTicker {
+	tc.RLock()
+	defer tc.RUnlock()
+
+	tickers := make(map[string]CachedTicker, len(tc.tickers))
+	for k, v := range tc.tickers {
+		tickers[k] = v
+	}
+	return tickers
+}
+
+// Get pairs that are not updated within maxAge.
+func (tc *TickerCache) Stale(maxAge time.Duration) (pairs []string) {
+	tc.RLock()
+	defer tc.RUnlock()
+
+	for k, v := range tc.tickers {
+		if time.Since(v.Updated) > maxAge {
+			pairs = append(pairs, k)
+		}
+	}
+	return
+}
+
+// Watch changes of pairs, or all pairs if none is given.
+func (tc *TickerCache) Watch(pairs ...string) *TickerWatch {
+	ch := make(chan CachedTicker, SUBSBUFFER)
+	w := &TickerWatch{
+		C:     ch,
+		ch:    ch,
+		pairs: make(map[string]bool),
+		cache: tc,
+	}
+
+	for _, pair := range pairs {
+		w.pairs[strings.ToUpper(pair)] = true
+	}
+
+	tc.Lock()
+	tc.watchers[w] = true
+	tc.Unlock()
+	return w
+}
+
+// Stop watching and close the watch channel.
+func (w *TickerWatch) Stop() {
+	tc := w.cache
+
+	tc.Lock()
+	defer tc.Unlock()
+
+	if tc.watchers[w] {
+		delete(tc.watchers, w)
+		close(w.ch)
+	}
+}
+
+// Stop updating the cache and close all watches.
+// It returns nil if successful.
+func (tc *TickerCache) Close() error {
+	err := tc.sub.Unsubscribe()
+
+	tc.Lock()
+	defer tc.Unlock()
+
+	for w := range tc.watchers {
+		delete(tc.watchers, w)
+		close(w.ch)
+	}
+	return err
+}
@@ -3,8 +3,11 @@ package poloniex
 import (
 	"encoding/json"
 	"errors"
+	"io"
+	"net"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -73,6 +76,47 @@ func (p *Poloniex) GetAccountBalances() (accounts Accounts, err error) {
 	return
 }
 
+type Transfer struct {
+	Success int    `json:"success"`
+	Message string `json:"message"`
+}
+
+// Transfer balance of currency between accounts.
+func (p *Poloniex) TransferBalance(currency string, amount decimal.Decimal, fromAccount, toAccount AccountType) (transfer Transfer, err error) {
+	if !fromAccount.valid() || !toAccount.valid() || fromAccount == toAccount {
+		err = Error(AccountError, string(fromAccount)+" -> "+string(toAccount))
+		return
+	}
+
+	amount = amount.Truncate(8)
+	if !amount.IsPositive() {
+		err = Error(OrderAmountError)
+		return
+	}
+
+	parameters := map[string]string{
+		"currency":    strings.ToUpper(currency),
+		"amount":      amount.StringFixed(8),
+		"fromAccount": string(fromAccount),
+		"toAccount":   string(toAccount),
+	}
+
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("transferBalance", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &transfer)
+	return
+}
+
 func (p *Poloniex) GetDepositAddresses() (depositaddresses map[string]string, err error) {
 	respch := make(chan []byte)
 	errch := make(chan error)
@@ -113,14 +157,128 @@ func (p *Poloniex) GenerateNewAddress(currency string) (newaddress NewAddress, e
 	return
 }
 
+type Deposit struct {
+	Currency      string          `json:"currency"`
+	Address       string          `json:"address"`
+	Amount        decimal.Decimal `json:"amount"`
+	Confirmations int             `json:"confirmations"`
+	TxID          string          `json:"txid"`
+	Timestamp     PoloTime        `json:"timestamp"`
+	Status        string          `json:"status"`
+}
+
+type Withdrawal struct {
+	WithdrawalNumber int64           `json:"withdrawalNumber"`
+	Currency         string          `json:"currency"`
+	Address          string          `json:"address"`
+	Amount           decimal.Decimal `json:"amount"`
+	Fee              decimal.Decimal `json:"fee"`
+	Timestamp        PoloTime        `json:"timestamp"`
+	Status           string          `json:"status"`
+	TxID             string          `json:"txid"`
+	IPAddress        string          `json:"ipAddress"`
+	PaymentID        string          `json:"paymentID"`
+}
+
+func (w *Withdrawal) UnmarshalJSON(b []byte) error {
+	type withdrawal Withdrawal
+
+	err := json.Unmarshal(b, (*withdrawal)(w))
+	if err != nil {
+		return err
+	}
+
+	// completed withdrawals have status "COMPLETE: <txid>".
+	if i := strings.Index(w.Status, ":"); i >= 0 {
+		if w.TxID == "" {
+			w.TxID = strings.TrimSpace(w.Status[i+1:])
+		}
+		w.Status = strings.TrimSpace(w.Status[:i])
+	}
+	return nil
+}
+
+type DepositsWithdrawals struct {
+	Deposits    []Deposit    `json:"deposits"`
+	Withdrawals []Withdrawal `json:"withdrawals"`
+}
+
+func (p *Poloniex) GetDepositsWithdrawals(start, end time.Time) (depositswithdrawals DepositsWithdrawals, err error) {
+	parameters := map[string]string{
+		"start": strconv.FormatInt(start.Unix(), 10),
+		"end":   strconv.FormatInt(end.Unix(), 10),
+	}
+
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("returnDepositsWithdrawals", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &depositswithdrawals)
+	return
+}
+
+type Withdraw struct {
+	Response string `json:"response"`
+}
+
+// Withdraw currency to address.
+// paymentID is optional, it is used by currencies like XMR.
+// The client must be created with EnableWithdrawals option.
+func (p *Poloniex) Withdraw(currency string, amount decimal.Decimal, address, paymentID string) (withdraw Withdraw, err error) {
+	if !p.withdrawals {
+		err = Error(WithdrawError)
+		return
+	}
+
+	amount = amount.Truncate(8)
+	if !amount.IsPositive() {
+		err = Error(OrderAmountError)
+		return
+	}
+
+	parameters := map[string]string{
+		"currency": strings.ToUpper(currency),
+		"amount":   amount.StringFixed(8),
+		"address":  address,
+	}
+
+	if paymentID != "" {
+		parameters["paymentId"] = paymentID
+	}
+
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("withdraw", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &withdraw)
+	return
+}
+
 type OpenOrder struct {
 	OrderNumber    string          `json:"orderNumber"`
-	Type           string          `json:"type"`
+	ClientOrderID  ClientOrderID   `json:"clientOrderId"`
+	Type           Side            `json:"type"`
 	Price          decimal.Decimal `json:"rate, string"`
 	StartingAmount decimal.Decimal `json:"startingAmount, string"`
 	Amount         decimal.Decimal `json:"amount, string"`
 	Total          decimal.Decimal `json:"total, string"`
-	Date           string          `json:"date"`
+	Date           PoloTime        `json:"date"`
 	Margin         int             `json:"margin"`
 }
 
@@ -193,17 +351,153 @@ func (p *Poloniex) CancelOrder(orderNumber string) (cancelorder CancelOrder, err
 	return
 }
 
+type CancelAllOrders struct {
+	Success      int           `json:"success"`
+	Message      string        `json:"message"`
+	OrderNumbers []json.Number `json:"orderNumbers"`
+}
+
+// Cancel all open orders in market, or in all markets if market is empty.
+// It returns the numbers of the cancelled orders.
+func (p *Poloniex) CancelAllOrders(market string) (orderNumbers []string, err error) {
+	var cancelall CancelAllOrders
+
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	parameters := make(map[string]string)
+	if market != "" {
+		parameters["currencyPair"] = strings.ToUpper(market)
+	}
+	go p.tradingRequest("cancelAllOrders", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &cancelall)
+	if err != nil {
+		return
+	}
+
+	for _, v := range cancelall.OrderNumbers {
+		orderNumbers = append(orderNumbers, v.String())
+	}
+	return
+}
+
+// Cancel orders in parallel within the rate limit,
+// trading requests get their nonces in send order.
+// It returns the numbers of the cancelled orders,
+// the failed ones are reported in the returned error.
+func (p *Poloniex) CancelOrders(orderNumbers ...string) (cancelled []string, err error) {
+	var failed []string
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	queue := make(chan string)
+	for i := 0; i < CANCELWORKERS; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for orderNumber := range queue {
+				resp, err := p.CancelOrder(orderNumber)
+
+				mutex.Lock()
+				if err != nil || resp.Success != 1 {
+					failed = append(failed, orderNumber)
+				} else {
+					cancelled = append(cancelled, orderNumber)
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for _, orderNumber := range orderNumbers {
+		queue <- orderNumber
+	}
+	close(queue)
+	wg.Wait()
+
+	if len(failed) > 0 {
+		err = Error(CancelOrdersError, strings.Join(failed, ", "))
+	}
+	return
+}
+
+// Cancel order by client order id.
+func (p *Poloniex) CancelOrderByClientID(id ClientOrderID) (cancelorder CancelOrder, err error) {
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	parameters := map[string]string{"clientOrderId": id.String()}
+	go p.tradingRequest("cancelOrder", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(resp, &cancelorder)
+	return
+}
+
+// Find open order by client order id.
+// Market "all" searches every market.
+func (p *Poloniex) GetOpenOrderByClientID(market string, id ClientOrderID) (openorder OpenOrder, err error) {
+	openorder, ok, err := p.findOpenOrder(market, id)
+	if err == nil && !ok {
+		err = Error(ClientOrderError, id.String())
+	}
+	return
+}
+
+// sub-function for finding open order by client order id.
+func (p *Poloniex) findOpenOrder(market string, id ClientOrderID) (openorder OpenOrder, ok bool, err error) {
+	var openorders []OpenOrder
+
+	if strings.ToLower(market) == "all" {
+		all, err := p.GetAllOpenOrders()
+		if err != nil {
+			return openorder, false, err
+		}
+		for _, v := range all {
+			openorders = append(openorders, v...)
+		}
+	} else {
+		openorders, err = p.GetOpenOrders(market)
+		if err != nil {
+			return
+		}
+	}
+
+	for _, v := range openorders {
+		if v.ClientOrderID == id {
+			return v, true, nil
+		}
+	}
+	return
+}
+
 type TradeHistory struct {
 	GlobalTradeID int             `json:"globalTradeId"`
 	TradeID       string          `json:"tradeId"`
-	Date          string          `json:"date"`
+	Date          PoloTime        `json:"date"`
 	Price         decimal.Decimal `json:"rate, string"`
 	Amount        decimal.Decimal `json:"amount, string"`
 	Total         decimal.Decimal `json:"total, string"`
 	Fee           decimal.Decimal `json:"fee,string"`
 	OrderNumber   decimal.Decimal `json:"orderNumber,string"`
-	Type          string          `json:"type"`
+	Type          Side            `json:"type"`
 	Category      string          `json:"category"`
+	ClientOrderID ClientOrderID   `json:"clientOrderId"`
+	Market        string          `json:"currencyPair"`
 }
 
 func (p *Poloniex) GetTradeHistory(market string, start, end time.Time, limit int) (tradehistory []TradeHistory, err error) {
@@ -230,16 +524,58 @@ func (p *Poloniex) GetTradeHistory(market string, start, end time.Time, limit in
 	return
 }
 
+// This method returns trade history of all markets.
+// Market field of the trades is set to their market.
+func (p *Poloniex) GetAllTradeHistory(start, end time.Time, limit int) (tradehistory map[string][]TradeHistory, err error) {
+	parameters := map[string]string{
+		"currencyPair": "all",
+		"start":        strconv.FormatInt(start.Unix(), 10),
+		"end":          strconv.FormatInt(end.Unix(), 10),
+		"limit":        strconv.Itoa(limit),
+	}
+
+	respch := make(chan []byte)
+	errch := make(chan error)
+
+	go p.tradingRequest("returnTradeHistory", parameters, respch, errch)
+
+	resp := <-respch
+	err = <-errch
+
+	if err != nil {
+		return
+	}
+
+	// no trade is returned as an empty list.
+	tradehistory = make(map[string][]TradeHistory)
+	if strings.TrimSpace(string(resp)) == "[]" {
+		return
+	}
+
+	err = json.Unmarshal(resp, &tradehistory)
+	if err != nil {
+		return
+	}
+
+	for k, v := range tradehistory {
+		for i := range v {
+			v[i].Market = k
+		}
+	}
+	return
+}
+
 type OrderTrade struct {
 	GlobalTradeID decimal.Decimal `json:"globalTradeId"`
 	TradeID       decimal.Decimal `json:"tradeId"`
 	Market        string          `json:"currencyPair"`
-	Type          string          `json:"type"`
+	Type          Side            `json:"type"`
 	Price         decimal.Decimal `json:"rate"`
 	Amount        decimal.Decimal `json:"amount"`
 	Total         decimal.Decimal `json:"total"`
 	Fee           decimal.Decimal `json:"fee"`
-	Date          string          `json:"date"`
+	Date          PoloTime        `json:"date"`
+	ClientOrderID ClientOrderID   `json:"clientOrderId"`
 }
 
 func (p *Poloniex) GetTradesByOrderID(orderNumber string) (ordertrades []OrderTrade, err error) {
@@ -261,14 +597,15 @@ func (p *Poloniex) GetTradesByOrderID(orderNumber string) (ordertrades []OrderTr
 }
 
 type OrderStat struct {
-	Status         string          `json:"status"`
+	Status         OrderStatus     `json:"status"`
 	Rate           decimal.Decimal `json:"rate"`
 	Amount         decimal.Decimal `json:"amount"`
 	CurrencyPair   string          `json:"currencyPair"`
-	Date           string          `json:"date"`
+	Date           PoloTime        `json:"date"`
 	Total          decimal.Decimal `json:"total"`
-	Type           string          `json:"type"`
+	Type           Side            `json:"type"`
 	StartingAmount decimal.Decimal `json:"startingAmount"`
+	ClientOrderID  ClientOrderID   `json:"clientOrderId"`
 }
 
 // error result
@@ -329,29 +666,214 @@ func (p *Poloniex) GetOrderStat(orderNumber string) (orderstat OrderStat, err er
 
 type ResultTrades struct {
 	Amount  decimal.Decimal `json:"amount"`
-	Date    string          `json:"date"`
+	Date    PoloTime        `json:"date"`
 	Rate    decimal.Decimal `json:"rate"`
 	Total   decimal.Decimal `json:"total"`
 	TradeID decimal.Decimal `json:"tradeId"`
-	Type    string          `json:"type"`
+	Type    Side            `json:"type"`
 }
 
 type Buy struct {
-	OrderNumber     string `json:"orderNumber"`
+	OrderNumber     string          `json:"orderNumber"`
+	ClientOrderID   ClientOrderID   `json:"clientOrderId"`
+	Market          string          `json:"currencyPair"`
+	AmountUnfilled  decimal.Decimal `json:"amountUnfilled"`
+	Fee             decimal.Decimal `json:"fee"`
 	ResultingTrades []ResultTrades
 }
 
-func (p *Poloniex) Buy(market string, price, amount float64) (buy Buy, err error) {
+// 64-bit client order id.
+// It is decoded from both JSON numbers and strings.
+type ClientOrderID uint64
+
+func (id *ClientOrderID) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*id = 0
+		return nil
+	}
+
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*id = ClientOrderID(v)
+	return nil
+}
+
+func (id ClientOrderID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// Order placement options.
+// Only one of FillOrKill, ImmediateOrCancel and PostOnly can be set.
+type OrderOptions struct {
+	FillOrKill        bool          // fill completely or cancel
+	ImmediateOrCancel bool          // fill as much as possible and cancel the rest
+	PostOnly          bool          // cancel if any part would fill immediately
+	ClientOrderID     ClientOrderID // optional, must be unique across open orders
+}
+
+// Check order options and add them to parameters.
+// At most one options value is accepted.
+func setOrderOptions(parameters map[string]string, opts []OrderOptions) error {
+	if len(opts) == 0 {
+		return nil
+	}
+
+	if len(opts) > 1 {
+		return Error(OrderOptionsError)
+	}
+
+	o := opts[0]
+	flags := 0
+	if o.FillOrKill {
+		flags++
+		parameters["fillOrKill"] = "1"
+	}
+	if o.ImmediateOrCancel {
+		flags++
+		parameters["immediateOrCancel"] = "1"
+	}
+	if o.PostOnly {
+		flags++
+		parameters["postOnly"] = "1"
+	}
+
+	if flags > 1 {
+		return Error(OrderFlagsError)
+	}
+
+	if o.ClientOrderID != 0 {
+		parameters["clientOrderId"] = o.ClientOrderID.String()
+	}
+	return nil
+}
+
+// Price and amount decimals of market.
+type Precision struct {
+	Price  int32 `json:"price"`
+	Amount int32 `json:"amount"`
+}
+
+// Precision of markets without explicit precision.
+var DefaultPrecision = Precision{Price: 8, Amount: 8}
+
+// Set price and amount decimals used for the orders of market.
+func (p *Poloniex) SetPrecision(market string, precision Precision) {
+	p.precisionMutex.Lock()
+	defer p.precisionMutex.Unlock()
+
+	if p.precisions == nil {
+		p.precisions = make(map[string]Precision)
+	}
+	p.precisions[strings.ToUpper(market)] = precision
+}
+
+// Get price and amount decimals of market.
+func (p *Poloniex) Precision(market string) Precision {
+	precision, _ := p.precision(market)
+	return precision
+}
+
+// Get precision of market and whether it was set with SetPrecision.
+func (p *Poloniex) precision(market string) (Precision, bool) {
+	p.precisionMutex.RLock()
+	defer p.precisionMutex.RUnlock()
+
+	if precision, ok := p.precisions[strings.ToUpper(market)]; ok {
+		return precision, true
+	}
+	return DefaultPrecision, false
+}
+
+// Format price of order side with the precision of market, see roundPrice.
+func (p *Poloniex) formatPrice(market string, price decimal.Decimal, side Side) (string, error) {
+	return formatPrice(price, p.Precision(market), side)
+}
+
+// Format amount with the precision of market.
+// Amount is truncated, so it never exceeds the given one.
+func (p *Poloniex) formatAmount(market string, amount decimal.Decimal) (string, error) {
+	return formatAmount(amount, p.Precision(market))
+}
+
+// Format price of order side with precision, see roundPrice.
+func formatPrice(price decimal.Decimal, precision Precision, side Side) (string, error) {
+	price, err := roundPrice(price, precision.Price, side)
+	if err != nil {
+		return "", err
+	}
+	return price.StringFixed(precision.Price), nil
+}
+
+// Round price of order side to places decimals.
+// Buy prices are rounded down and sell prices up, so the limit is never
+// worse than the given one. Prices of orders with unknown side must fit.
+func roundPrice(price decimal.Decimal, places int32, side Side) (decimal.Decimal, error) {
+	rounded := price.Truncate(places)
+
+	switch side {
+	case SideBuy:
+		rounded = price.Shift(places).Floor().Shift(-places)
+	case SideSell:
+		rounded = price.Shift(places).Ceil().Shift(-places)
+	default:
+		if !rounded.Equal(price) {
+			return rounded, Error(OrderPricePrecisionError, price.String())
+		}
+	}
+
+	if !rounded.IsPositive() {
+		return rounded, Error(OrderPriceError)
+	}
+	return rounded, nil
+}
+
+// Format amount with precision.
+func formatAmount(amount decimal.Decimal, precision Precision) (string, error) {
+	amount = amount.Truncate(precision.Amount)
+	if !amount.IsPositive() {
+		return "", Error(OrderAmountError)
+	}
+	return amount.StringFixed(precision.Amount), nil
+}
+
+// sub-function for order placement.
+func (p *Poloniex) placeOrder(command, market string, price, amount decimal.Decimal, opts []OrderOptions) (buy Buy, err error) {
+	if p.validation {
+		err = p.ValidateOrder(market, Side(command), price, amount)
+		if err != nil {
+			return
+		}
+	}
+
+	rate, err := p.formatPrice(market, price, Side(command))
+	if err != nil {
+		return
+	}
+
+	quantity, err := p.formatAmount(market, amount)
+	if err != nil {
+		return
+	}
+
 	parameters := map[string]string{
 		"currencyPair": strings.ToUpper(market),
-		"rate":         strconv.FormatFloat(float64(price), 'f', 8, 64),
-		"amount":       strconv.FormatFloat(float64(amount), 'f', 8, 64),
+		"rate":         rate,
+		"amount":       quantity,
+	}
+
+	err = setOrderOptions(parameters, opts)
+	if err != nil {
+		return
 	}
 
 	respch := make(chan []byte)
 	errch := make(chan error)
 
-	go p.tradingRequest("buy", parameters, respch, errch)
+	go p.tradingRequest(command, parameters, respch, errch)
 
 	resp := <-respch
 	err = <-errch
@@ -364,19 +886,248 @@ func (p *Poloniex) Buy(market string, price, amount float64) (buy Buy, err error
 	return
 }
 
+// Place buy order.
+// Optional order options set the order flags and client order id.
+func (p *Poloniex) Buy(market string, price, amount float64, opts ...OrderOptions) (buy Buy, err error) {
+	return p.BuyDecimal(market, decimal.NewFromFloat(price), decimal.NewFromFloat(amount), opts...)
+}
+
+// Place buy order with decimal price and amount.
+// They are formatted with the precision of market.
+func (p *Poloniex) BuyDecimal(market string, price, amount decimal.Decimal, opts ...OrderOptions) (buy Buy, err error) {
+	return p.placeOrder("buy", market, price, amount, opts)
+}
+
 type Sell Buy
 
-func (p *Poloniex) Sell(market string, price, amount float64) (sell Sell, err error) {
+// Place sell order.
+// Optional order options set the order flags and client order id.
+func (p *Poloniex) Sell(market string, price, amount float64, opts ...OrderOptions) (sell Sell, err error) {
+	return p.SellDecimal(market, decimal.NewFromFloat(price), decimal.NewFromFloat(amount), opts...)
+}
+
+// Place sell order with decimal price and amount.
+// They are formatted with the precision of market.
+func (p *Poloniex) SellDecimal(market string, price, amount decimal.Decimal, opts ...OrderOptions) (sell Sell, err error) {
+	buy, err := p.placeOrder("sell", market, price, amount, opts)
+	return Sell(buy), err
+}
+
+// It reports whether the request or its response was lost on the way,
+// so the order may or may not have been placed.
+func isTransportError(err error) bool {
+	if err.Error() == ConnectError || err == io.ErrUnexpectedEOF {
+		return true
+	}
+	_, ok := err.(net.Error)
+	return ok
+}
+
+// Find order placed with client order id since start,
+// in the open orders first and then in the trade history.
+func (p *Poloniex) findPlacedOrder(market string, id ClientOrderID, start time.Time) (buy Buy, ok bool, err error) {
+	openorder, ok, err := p.findOpenOrder(market, id)
+	if err != nil {
+		return
+	}
+
+	if ok {
+		buy.OrderNumber = openorder.OrderNumber
+		buy.ClientOrderID = id
+		buy.Market = strings.ToUpper(market)
+		return
+	}
+
+	// 10000 is the largest page of the trade history.
+	trades, err := p.GetTradeHistory(market, start, time.Now(), 10000)
+	if err != nil {
+		return
+	}
+
+	for _, v := range trades {
+		if v.ClientOrderID != id {
+			continue
+		}
+
+		tradeID, _ := decimal.NewFromString(v.TradeID)
+
+		buy.OrderNumber = v.OrderNumber.String()
+		buy.ClientOrderID = id
+		buy.Market = strings.ToUpper(market)
+		buy.ResultingTrades = append(buy.ResultingTrades, ResultTrades{
+			Amount:  v.Amount,
+			Date:    v.Date,
+			Rate:    v.Price,
+			Total:   v.Total,
+			TradeID: tradeID,
+			Type:    v.Type,
+		})
+		ok = true
+	}
+	return
+}
+
+// Place order and retry if the response is lost.
+// Only transport errors are retried, after ORDERRETRYWAIT seconds times
+// the attempt. Before each retry the order is looked up by its client
+// order id, so it is never placed twice. If the lookup fails, the error
+// of the placement is returned.
+func (p *Poloniex) placeOnce(command, market string, price, amount float64, opts OrderOptions) (buy Buy, err error) {
+	if opts.ClientOrderID == 0 {
+		err = Error(ClientOrderIDError)
+		return
+	}
+
+	start := time.Now().Add(-time.Minute)
+	for i := 0; i < ORDERRETRIES; i++ {
+		if i > 0 {
+			time.Sleep(time.Second * ORDERRETRYWAIT * time.Duration(i))
+
+			placed, ok, lerr := p.findPlacedOrder(market, opts.ClientOrderID, start)
+			if lerr != nil {
+				return
+			}
+			if ok {
+				return placed, nil
+			}
+		}
+
+		if command == "buy" {
+			buy, err = p.Buy(market, price, amount, opts)
+		} else {
+			var sell Sell
+			sell, err = p.Sell(market, price, amount, opts)
+			buy = Buy(sell)
+		}
+
+		if err == nil || !isTransportError(err) {
+			return
+		}
+	}
+	return
+}
+
+// Place buy order idempotently.
+// opts.ClientOrderID is required, it is used to find out whether an
+// order whose response is lost was placed before trying again.
+func (p *Poloniex) BuyOnce(market string, price, amount float64, opts OrderOptions) (buy Buy, err error) {
+	return p.placeOnce("buy", market, price, amount, opts)
+}
+
+// Place sell order idempotently.
+// opts.ClientOrderID is required, it is used to find out whether an
+// order whose response is lost was placed before trying again.
+func (p *Poloniex) SellOnce(market string, price, amount float64, opts OrderOptions) (sell Sell, err error) {
+	buy, err := p.placeOnce("sell", market, price, amount, opts)
+	return Sell(buy), err
+}
+
+type Move Buy
+
+func (m *Move) UnmarshalJSON(b []byte) error {
+	var msg struct {
+		Buy
+		ResultingTrades json.RawMessage `json:"resultingTrades"`
+	}
+
+	err := json.Unmarshal(b, &msg)
+	if err != nil {
+		return err
+	}
+
+	*m = Move(msg.Buy)
+	m.ResultingTrades = nil
+
+	if len(msg.ResultingTrades) == 0 {
+		return nil
+	}
+
+	// moveOrder returns resulting trades by market.
+	trades := make(map[string][]ResultTrades)
+	err = json.Unmarshal(msg.ResultingTrades, &trades)
+	if err != nil {
+		return json.Unmarshal(msg.ResultingTrades, &m.ResultingTrades)
+	}
+
+	for k, v := range trades {
+		m.Market = k
+		m.ResultingTrades = append(m.ResultingTrades, v...)
+	}
+	return nil
+}
+
+// Cancel order and place a new one with the same type and market atomically.
+// Amount 0 keeps the remaining amount of the order.
+// FillOrKill is not supported by the exchange for moved orders.
+// The market and side of the order are unknown here, so price and amount
+// are formatted with DefaultPrecision and the price must fit it.
+//
+// Deprecated: Use MoveOrderDecimal, which formats price and amount
+// with the precision of the market.
+func (p *Poloniex) MoveOrder(orderNumber string, price, amount float64, opts ...OrderOptions) (move Move, err error) {
+	var quantity string
+
+	rate, err := formatPrice(decimal.NewFromFloat(price), DefaultPrecision, "")
+	if err != nil {
+		return
+	}
+
+	if amount > 0 {
+		quantity, err = formatAmount(decimal.NewFromFloat(amount), DefaultPrecision)
+		if err != nil {
+			return
+		}
+	}
+	return p.moveOrder(orderNumber, rate, quantity, opts)
+}
+
+// Move order of market with decimal price and amount.
+// They are formatted with the precision of market, the side of the order
+// is unknown here, so the price must fit it. Zero amount keeps the
+// remaining amount of the order.
+func (p *Poloniex) MoveOrderDecimal(market, orderNumber string, price, amount decimal.Decimal, opts ...OrderOptions) (move Move, err error) {
+	var quantity string
+
+	rate, err := p.formatPrice(market, price, "")
+	if err != nil {
+		return
+	}
+
+	if !amount.IsZero() {
+		quantity, err = p.formatAmount(market, amount)
+		if err != nil {
+			return
+		}
+	}
+	return p.moveOrder(orderNumber, rate, quantity, opts)
+}
+
+// sub-function for moving order.
+// Empty amount keeps the remaining amount of the order.
+func (p *Poloniex) moveOrder(orderNumber, rate, amount string, opts []OrderOptions) (move Move, err error) {
 	parameters := map[string]string{
-		"currencyPair": strings.ToUpper(market),
-		"rate":         strconv.FormatFloat(float64(price), 'f', 8, 64),
-		"amount":       strconv.FormatFloat(float64(amount), 'f', 8, 64),
+		"orderNumber": orderNumber,
+		"rate":        rate,
+	}
+
+	if amount != "" {
+		parameters["amount"] = amount
+	}
+
+	if len(opts) > 0 && opts[0].FillOrKill {
+		err = Error(MoveFlagsError)
+		return
+	}
+
+	err = setOrderOptions(parameters, opts)
+	if err != nil {
+		return
 	}
 
 	respch := make(chan []byte)
 	errch := make(chan error)
 
-	go p.tradingRequest("sell", parameters, respch, errch)
+	go p.tradingRequest("moveOrder", parameters, respch, errch)
 
 	resp := <-respch
 	err = <-errch
@@ -385,6 +1136,6 @@ func (p *Poloniex) Sell(market string, price, amount float64) (sell Sell, err er
 		return
 	}
 
-	err = json.Unmarshal(resp, &sell)
+	err = json.Unmarshal(resp, &move)
 	return
 }
@@ -0,0 +1,154 @@
+package poloniex
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMoveUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		market string
+		trades []string // trade ids
+	}{
+		{
+			"trades by market",
+			`{"success":1,"orderNumber":"123","resultingTrades":{"BTC_ETH":[{"amount":"1","date":"2026-10-12 00:00:00","rate":"0.1","total":"0.1","tradeID":"5","type":"buy"},{"amount":"2","date":"2026-10-12 00:00:01","rate":"0.1","total":"0.2","tradeID":"6","type":"buy"}]}}`,
+			"BTC_ETH", []string{"5", "6"},
+		},
+		{
+			"trades list",
+			`{"success":1,"orderNumber":"123","resultingTrades":[{"amount":"1","date":"2026-10-12 00:00:00","rate":"0.1","total":"0.1","tradeID":"7","type":"sell"}]}`,
+			"", []string{"7"},
+		},
+		{"no trades", `{"success":1,"orderNumber":"123","resultingTrades":{}}`, "", nil},
+		{"missing trades", `{"success":1,"orderNumber":"123"}`, "", nil},
+	}
+
+	for _, tt := range tests {
+		var got Move
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		if got.OrderNumber != "123" || got.Market != tt.market || len(got.ResultingTrades) != len(tt.trades) {
+			t.Errorf("%s: got %+v", tt.name, got)
+			continue
+		}
+		for i, v := range got.ResultingTrades {
+			if v.TradeID.String() != tt.trades[i] {
+				t.Errorf("%s: trade %d id = %s, want %s", tt.name, i, v.TradeID, tt.trades[i])
+			}
+		}
+	}
+}
+
+func TestWithdrawalUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in     string
+		status string
+		txid   string
+	}{
+		{`{"status":"COMPLETE: 0xabc"}`, "COMPLETE", "0xabc"},
+		{`{"status":"COMPLETE: 0xabc","txid":"0xdef"}`, "COMPLETE", "0xdef"},
+		{`{"status":"PENDING"}`, "PENDING", ""},
+		{`{"status":"COMPLETE: ERROR"}`, "COMPLETE", "ERROR"},
+	}
+
+	for _, tt := range tests {
+		var got Withdrawal
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		if got.Status != tt.status || got.TxID != tt.txid {
+			t.Errorf("Unmarshal(%s) = status %q, txid %q, want %q, %q", tt.in, got.Status, got.TxID, tt.status, tt.txid)
+		}
+	}
+}
+
+func TestFormatPrice(t *testing.T) {
+	precision := Precision{Price: 4, Amount: 2}
+
+	tests := []struct {
+		price string
+		side  Side
+		want  string
+		ok    bool
+	}{
+		{"1.23456", SideBuy, "1.2345", true},
+		{"1.23454", SideBuy, "1.2345", true},
+		{"1.23451", SideSell, "1.2346", true},
+		{"1.23456", SideSell, "1.2346", true},
+		{"1.2345", SideBuy, "1.2345", true},
+		{"1.2345", SideSell, "1.2345", true},
+		{"1.2", "", "1.2000", true},
+		{"1.23456", "", "", false},
+		{"0.00001", SideBuy, "", false},
+		{"0.00001", SideSell, "0.0001", true},
+		{"0", SideSell, "", false},
+		{"-1", SideBuy, "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := formatPrice(decimal.RequireFromString(tt.price), precision, tt.side)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("formatPrice(%s, %q) = %q, %v, want %q, ok %v", tt.price, tt.side, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	precision := Precision{Price: 4, Amount: 2}
+
+	tests := []struct {
+		amount string
+		want   string
+		ok     bool
+	}{
+		{"1.239", "1.23", true},
+		{"1", "1.00", true},
+		{"0.009", "", false},
+		{"-1", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := formatAmount(decimal.RequireFromString(tt.amount), precision)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("formatAmount(%s) = %q, %v, want %q, ok %v", tt.amount, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsTransportError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{Error(ConnectError), true},
+		{io.ErrUnexpectedEOF, true},
+		{timeoutError{}, true},
+		{Error(ServerError, "Not enough BTC."), false},
+		{Error(OrderPriceError), false},
+		{Error(OrderPricePrecisionError, "1.23456"), false},
+		{errors.New("invalid character"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isTransportError(tt.err); got != tt.want {
+			t.Errorf("isTransportError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -3,11 +3,54 @@ package poloniex
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
 	"time"
 )
 
 var ZeroTime = time.Time{}
 
+// Layout of the dates returned by the exchange in UTC.
+const DateLayout = "2006-01-02 15:04:05"
+
+// Time of the exchange models.
+// It is decoded from "2006-01-02 15:04:05" UTC date strings and
+// unix timestamps, and encoded as a date string.
+type PoloTime struct {
+	time.Time
+}
+
+func (t *PoloTime) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		t.Time = time.Unix(sec, 0).UTC()
+		return nil
+	}
+
+	date, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return err
+	}
+
+	t.Time = date
+	return nil
+}
+
+func (t PoloTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+	return []byte(`"` + t.UTC().Format(DateLayout) + `"`), nil
+}
+
+func (t PoloTime) String() string {
+	return t.UTC().Format(DateLayout)
+}
+
 func intInSlice(a int, list []int) bool {
 	for _, b := range list {
 		if b == a {
@@ -0,0 +1,54 @@
+package poloniex
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPoloTimeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{`"2026-10-12 13:14:15"`, time.Date(2026, 10, 12, 13, 14, 15, 0, time.UTC), true},
+		{`1791810855`, time.Unix(1791810855, 0).UTC(), true},
+		{`"1791810855"`, time.Unix(1791810855, 0).UTC(), true},
+		{`0`, time.Unix(0, 0).UTC(), true},
+		{`""`, time.Time{}, true},
+		{`null`, time.Time{}, true},
+		{`"2026-10-12T13:14:15Z"`, time.Time{}, false},
+		{`"yesterday"`, time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		var got PoloTime
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err == nil) != tt.ok {
+			t.Errorf("Unmarshal(%s) error = %v, want ok %v", tt.in, err, tt.ok)
+			continue
+		}
+		if tt.ok && (!got.Equal(tt.want) || got.Location() != time.UTC) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, got.Time, tt.want)
+		}
+	}
+}
+
+func TestPoloTimeMarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   PoloTime
+		want string
+	}{
+		{PoloTime{time.Date(2026, 10, 12, 13, 14, 15, 0, time.UTC)}, `"2026-10-12 13:14:15"`},
+		{PoloTime{time.Date(2026, 10, 12, 15, 14, 15, 0, time.FixedZone("CEST", 7200))}, `"2026-10-12 13:14:15"`},
+		{PoloTime{}, `""`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.in)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("Marshal(%v) = %s, %v, want %s", tt.in.Time, got, err, tt.want)
+		}
+	}
+}